@@ -0,0 +1,113 @@
+package main
+
+import (
+	"flag"
+	"log"
+	"path/filepath"
+
+	"github.com/myusername/dart-statistic-scraper/internal/utils"
+	"github.com/myusername/dart-statistic-scraper/pkg/stats"
+	"github.com/myusername/dart-statistic-scraper/pkg/storage"
+)
+
+// Reports available to the aggregate subcommand
+const (
+	reportSeason = "season"
+	reportLeague = "league"
+	reportMVP    = "mvp"
+	reportFeats  = "feats"
+	reportAwards = "awards"
+)
+
+// runAggregate implements the aggregate subcommand: it loads every stored week
+// and runs the selected reports without fetching or parsing any pages
+func runAggregate(args []string) {
+	fs := flag.NewFlagSet("aggregate", flag.ExitOnError)
+	outputFlag := fs.String("output", ".", "Output directory holding the store; reports are written to its csv directory")
+	dbFlag := fs.String("db", "", "SQLite database holding the weekly stats (default: the output's store directory)")
+	divisionFlag := fs.String("division", "", "Division to report on, named after its standings page, when several -url pages were scraped together")
+	reportsFlag := fs.String("reports", "season,league,mvp,feats", "Comma-separated reports to run: season, league, mvp, feats, awards")
+	metricFlag := fs.String("metric", stats.MetricPPD, "Metric used to pick team MVPs")
+	weightingFlag := fs.String("weighting", "games", "How averages are weighted: games, equal or darts")
+	segmentsFlag := fs.String("segments", "", "Season segments as name:first-last, for weeks stored without one")
+	segmentFlag := fs.String("segment", "", "Only report on this season segment (default: all weeks)")
+	excludeTeamsFlag := fs.String("exclude-teams", "", "Comma-separated teams to leave out of all output")
+	excludePlayersFlag := fs.String("exclude-players", "", "Comma-separated players to leave out of all output")
+	fs.Parse(args)
+
+	log.SetFlags(log.Ldate | log.Ltime | log.Lshortfile)
+
+	weighting, err := stats.ParseWeighting(*weightingFlag)
+	if err != nil {
+		log.Fatalf("Invalid -weighting: %v", err)
+	}
+	stats.AverageWeighting = weighting
+
+	store, closeStore, err := openStore(*dbFlag, *outputFlag)
+	if err != nil {
+		log.Fatalf("Failed to open store: %v", err)
+	}
+	defer closeStore()
+	divisionStore, err := store.Division(*divisionFlag)
+	if err != nil {
+		log.Fatalf("Failed to open store for division %s: %v", *divisionFlag, err)
+	}
+	weeks, err := storage.LoadAll(divisionStore)
+	if err != nil {
+		log.Fatalf("Failed to load stored weeks: %v", err)
+	}
+	log.Printf("Loaded %d weeks from the store", len(weeks))
+
+	// Restrict the reports to one season segment if requested
+	segments, err := stats.ParseSegments(*segmentsFlag)
+	if err != nil {
+		log.Fatalf("Invalid -segments: %v", err)
+	}
+	stats.TagSegments(weeks, segments)
+	weeks = stats.FilterSegment(weeks, *segmentFlag)
+	if *segmentFlag != "" {
+		log.Printf("Reporting on %d weeks in segment %s", len(weeks), *segmentFlag)
+	}
+
+	// Apply exclusions to the stored weeks
+	exclusions := stats.NewExclusions(splitList(*excludeTeamsFlag), splitList(*excludePlayersFlag))
+	for i := range weeks {
+		weeks[i] = exclusions.FilterWeeklyStats(weeks[i])
+	}
+
+	csvDir := filepath.Join(*outputFlag, "csv")
+	if err := utils.OutputFS.MkdirAll(csvDir, 0755); err != nil {
+		log.Fatalf("Failed to create directory %s: %v", csvDir, err)
+	}
+
+	for _, report := range splitList(*reportsFlag) {
+		switch report {
+		case reportSeason:
+			filename := filepath.Join(csvDir, "season_totals.csv")
+			if err := utils.SaveSeasonTotalsToCSV(stats.SeasonTotals(weeks), filename); err != nil {
+				log.Printf("Error saving season totals: %v", err)
+			} else {
+				log.Printf("Saved season totals to %s", filename)
+			}
+		case reportLeague:
+			filename := filepath.Join(csvDir, "league_totals.csv")
+			if err := utils.SaveLeagueTotalsToCSV(stats.LeagueWeeklyTotals(weeks), filename); err != nil {
+				log.Printf("Error saving league totals: %v", err)
+			} else {
+				log.Printf("Saved league totals to %s", filename)
+			}
+		case reportMVP:
+			utils.DisplayTeamMVPs(*metricFlag, stats.TeamMVPs(weeks, *metricFlag))
+		case reportFeats:
+			for _, weeklyStats := range weeks {
+				utils.DisplayNotableFeats(weeklyStats.Week, stats.NotableFeats(weeklyStats))
+			}
+		case reportAwards:
+			utils.DisplaySeasonAwards(stats.SeasonAwards(weeks))
+		default:
+			log.Printf("Unknown report %q", report)
+		}
+	}
+
+	log.Println("Aggregation complete")
+}
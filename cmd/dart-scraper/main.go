@@ -2,16 +2,26 @@
 package main
 
 import (
+	"errors"
 	"flag"
 	"fmt"
 	"log"
 	"os"
 	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/myusername/dart-statistic-scraper/internal/utils"
+	"github.com/myusername/dart-statistic-scraper/pkg/app"
 	"github.com/myusername/dart-statistic-scraper/pkg/models"
+	"github.com/myusername/dart-statistic-scraper/pkg/parquetexport"
 	"github.com/myusername/dart-statistic-scraper/pkg/parser"
 	"github.com/myusername/dart-statistic-scraper/pkg/scraper"
+	"github.com/myusername/dart-statistic-scraper/pkg/stats"
+	"github.com/myusername/dart-statistic-scraper/pkg/storage"
+	"github.com/myusername/dart-statistic-scraper/pkg/vfs"
 )
 
 // Version is set during build using ldflags
@@ -19,10 +29,80 @@ var (
 	version = "dev"
 )
 
+// Pages scraped when no others are given
+const (
+	defaultStandingsURL = "https://macdleagues.com/DartStandings/FALL2024standings/FALL2024%2024SUN1OZCounty.html"
+	defaultScheduleURL  = "https://macdleagues.com/DartSchedules/FALL2024Schedules/FALL2024%2024SUN1.pdf"
+)
+
+// Output formats selectable with -format
+const (
+	formatTable = "table"
+	formatCSV   = "csv"
+	formatJSON  = "json"
+)
+
 func main() {
+	// Re-run reports from stored data without scraping
+	if len(os.Args) > 1 && os.Args[1] == "aggregate" {
+		runAggregate(os.Args[2:])
+		return
+	}
+
 	// Define command-line flags
 	versionFlag := flag.Bool("version", false, "Print version information and exit")
+	dryRunFlag := flag.Bool("dry-run", false, "Fetch and print results without writing any files or directories")
 	outputFlag := flag.String("output", "", "Output directory for CSV files (default: current directory)")
+	currentWeekFlag := flag.Int("current-week", 0, "Week to treat as current for display (default: highest parsed week)")
+	var urlFlags stringList
+	flag.Var(&urlFlags, "url", "Standings index page to scrape (repeatable; default: the OZ County division)")
+	urlFileFlag := flag.String("url-file", "", "File listing standings index pages to scrape, one per line")
+	scheduleURLFlag := flag.String("schedule-url", defaultScheduleURL, "Schedule PDF to download")
+	sinceFlag := flag.String("since", "", "Only process weeks scheduled on or after this date (YYYY-MM-DD)")
+	crawlDepthFlag := flag.Int("crawl-depth", 0, "Follow standings links found on standings pages this many levels deep (0 disables)")
+	latestOnlyFlag := flag.Bool("latest-only", false, "Only scrape weeks newer than the latest stored week (or just the newest week on a first run)")
+	weeksFlag := flag.String("weeks", "", "Only process these weeks, e.g. 10-12 or 3,5,7 (default: all)")
+	dbFlag := flag.String("db", "", "SQLite database to keep weekly stats in (default with -store: JSON files in the output's store directory)")
+	storeFlag := flag.Bool("store", false, "Keep weekly stats for later runs (implied by -db, -changed-only and -latest-only)")
+	formatFlag := flag.String("format", formatTable, "Output for each week: table (print and save CSV), csv (save CSV only) or json (save JSON only)")
+	sortFlag := flag.String("sort", "ppd", "Order players within each team by ppd, mpr, wins, winpct (highest first) or name")
+	changedOnlyFlag := flag.Bool("changed-only", false, "Only display players whose stats changed since the last run")
+	excludeTeamsFlag := flag.String("exclude-teams", "", "Comma-separated teams to leave out of all output")
+	excludePlayersFlag := flag.String("exclude-players", "", "Comma-separated players to leave out of all output")
+	compareTopFlag := flag.Int("compare-top", 0, "Show the top N players across all divisions (0 disables)")
+	mostImprovedFlag := flag.Int("most-improved", 0, "Show the N players whose PPD rose most since the previous week (0 disables)")
+	minGamesFlag := flag.Int("min-games", 0, "Leave players with fewer games out of displays, leaderboards and exports")
+	leaderboardFlag := flag.Int("leaderboard", 0, "Show the top N players of the current week by PPD and by MPR (0 disables)")
+	compareMetricFlag := flag.String("compare-metric", stats.MetricPPD, "Metric used to rank players across divisions")
+	exportProfilesFlag := flag.String("export-profiles", "", "JSON file of additional CSV export profiles")
+	exportProfileFlag := flag.String("export-profile", "", "Also save each week's CSV using this export profile")
+	boxScoreFlag := flag.Int("box-score", 0, "Also print a compact box score with each team's top N players (0 disables)")
+	markdownFlag := flag.Bool("markdown", false, "Also save each week as a Markdown page, e.g. for a wiki")
+	sortableHTMLFlag := flag.Bool("sortable-html", false, "Also save each week as a self-contained sortable HTML page")
+	var weekPatterns stringList
+	flag.Var(&weekPatterns, "week-pattern", "Regular expression capturing the week number in standings URLs (repeatable, tried in order)")
+	proxyFlag := flag.String("proxy", "", "Proxy URL for all requests (default: from HTTP_PROXY/HTTPS_PROXY)")
+	httpTimeoutFlag := flag.Duration("http-timeout", scraper.HTTPTimeout, "Maximum time a single request, such as a PDF download, may take")
+	requestIntervalFlag := flag.Duration("request-interval", 500*time.Millisecond, "Minimum time between requests to the league site")
+	userAgentFlag := flag.String("user-agent", scraper.UserAgent, "User-Agent header sent with every request")
+	var softNotFound stringList
+	flag.Var(&softNotFound, "soft-404", "Phrase marking a fetched page as missing, e.g. \"Page Not Found\" (repeatable)")
+	var extraHeaders stringList
+	flag.Var(&extraHeaders, "header", "Extra request header as \"Name: value\", e.g. a Cookie the site requires (repeatable)")
+	var playerTeams stringList
+	flag.Var(&playerTeams, "player-team", "Assign a player to a team as NAME=TEAM, e.g. \"Steve Wheelock=BRIDGE INN 2\" (repeatable)")
+	scheduleCSVFlag := flag.String("schedule-csv", "", "Corrected schedule CSV that overrides the parsed schedule where they conflict")
+	feedFlag := flag.Bool("feed", false, "Also save an Atom feed of each week's top performers as feed.xml")
+	feedTitleFlag := flag.String("feed-title", "Dart League Weekly Results", "Title of the -feed Atom feed")
+	allWeeksCSVFlag := flag.Bool("all-weeks-csv", false, "Also save every week to a single csv/all_weeks.csv")
+	parquetFlag := flag.Bool("parquet", false, "Also save the season as season.parquet, one row per player per week")
+	cacheFlag := flag.Bool("cache", false, "Keep a copy of every fetched page in the cache directory for later -offline runs")
+	offlineFlag := flag.Bool("offline", false, "Use only cached pages and never make network requests")
+	segmentsFlag := flag.String("segments", "", "Season segments as name:first-last, e.g. first:1-13,second:14-26")
+	weightingFlag := flag.String("weighting", "games", "How averages are weighted: games, equal or darts")
+	seasonFlag := flag.String("season", scraper.SeasonPrefix, "Season name standings links must contain, e.g. Spring2025")
+	linkPatternFlag := flag.String("link-pattern", "", "Regular expression selecting standings links (overrides -season)")
+	teamAliasesFlag := flag.String("team-aliases", "", "JSON file mapping team name spellings to canonical names (default: bundled aliases)")
 	flag.Parse()
 
 	// Print version and exit if requested
@@ -36,11 +116,25 @@ func main() {
 	log.Println("Dart Standings Scraper starting...")
 	log.Printf("Version: %s", version)
 
+	// Keep every write in memory during a dry run; files already on disk, like
+	// cached pages, can still be read
+	if *dryRunFlag {
+		log.Println("Dry run: no files or directories will be written")
+		overlay := vfs.NewOverlayFS(vfs.OS{})
+		utils.OutputFS = overlay
+		scraper.OutputFS = overlay
+		parquetexport.OutputFS = overlay
+		if *formatFlag != formatTable {
+			log.Printf("Dry run: printing tables instead of -format %s", *formatFlag)
+			*formatFlag = formatTable
+		}
+	}
+
 	// Create output directory if specified
 	outputDir := "."
 	if *outputFlag != "" {
 		outputDir = *outputFlag
-		err := os.MkdirAll(outputDir, 0755)
+		err := utils.OutputFS.MkdirAll(outputDir, 0755)
 		if err != nil {
 			log.Fatalf("Failed to create output directory: %v", err)
 		}
@@ -50,163 +144,545 @@ func main() {
 	// Create subdirectories for different file types
 	htmlDir := filepath.Join(outputDir, "html")
 	csvDir := filepath.Join(outputDir, "csv")
+	jsonDir := filepath.Join(outputDir, "json")
+	markdownDir := filepath.Join(outputDir, "markdown")
 	pdfDir := filepath.Join(outputDir, "pdf")
 
+	// Check the output format before doing any work
+	switch *formatFlag {
+	case formatTable, formatCSV, formatJSON:
+	default:
+		log.Fatalf("Invalid -format %q: must be table, csv or json", *formatFlag)
+	}
+
 	// Create the directories
-	for _, dir := range []string{htmlDir, csvDir, pdfDir} {
-		if err := os.MkdirAll(dir, 0755); err != nil {
+	dirs := []string{htmlDir, csvDir, pdfDir}
+	if *formatFlag == formatJSON {
+		dirs = append(dirs, jsonDir)
+	}
+	if *markdownFlag {
+		dirs = append(dirs, markdownDir)
+	}
+	for _, dir := range dirs {
+		if err := utils.OutputFS.MkdirAll(dir, 0755); err != nil {
 			log.Fatalf("Failed to create directory %s: %v", dir, err)
 		}
 	}
 
-	// Initialize parser with fetch function
-	parser.FetchURL = scraper.FetchURL
+	// Order players the way the user asked
+	sortKey, err := utils.ParseSortKey(*sortFlag)
+	if err != nil {
+		log.Fatalf("Invalid -sort: %v", err)
+	}
 
-	// PDF schedule URL
-	scheduleURL := "https://macdleagues.com/DartSchedules/FALL2024Schedules/FALL2024%2024SUN1.pdf"
-	localPDFPath := filepath.Join(pdfDir, "fall2024_schedule.pdf")
+	// Restrict processing to the requested weeks
+	weekSet, err := parseWeekSpec(*weeksFlag)
+	if err != nil {
+		log.Fatalf("Invalid -weeks: %v", err)
+	}
 
-	// Check if we already have the PDF
-	var schedules []models.MatchSchedule
-	if _, err := os.Stat(localPDFPath); os.IsNotExist(err) {
-		// Download the PDF if it doesn't exist
-		log.Printf("Attempting to download schedule PDF from %s", scheduleURL)
-		err := scraper.DownloadPDF(scheduleURL, localPDFPath)
+	// Skip weeks scheduled before the given date
+	var since time.Time
+	if *sinceFlag != "" {
+		since, err = time.Parse("2006-01-02", *sinceFlag)
 		if err != nil {
-			log.Printf("Error downloading PDF schedule: %v. Using fallback manual schedule.", err)
-			schedules = parser.ParseScheduleManually()
+			log.Fatalf("Invalid -since: %v", err)
 		}
 	}
 
-	// Process the schedule PDF
-	if len(schedules) == 0 {
-		pdfText, err := parser.ReadPDFText(localPDFPath)
+	// Open the store holding the stats from previous runs, but only when a
+	// flag keeps or reads them
+	var store storage.Store
+	if *dbFlag != "" || *storeFlag || *changedOnlyFlag || *latestOnlyFlag {
+		dbPath := *dbFlag
+		if *dryRunFlag && dbPath != "" {
+			log.Printf("Dry run: not updating database %s", dbPath)
+			dbPath = ""
+		}
+		var closeStore func()
+		store, closeStore, err = openStore(dbPath, outputDir)
 		if err != nil {
-			log.Printf("Error reading PDF text: %v. Using fallback manual schedule.", err)
-			schedules = parser.ParseScheduleManually()
-		} else {
-			// Extract schedule information from the PDF text
-			schedules = parser.ExtractScheduleFromText(pdfText)
+			log.Fatalf("Failed to open store: %v", err)
+		}
+		defer closeStore()
+	}
 
-			// If no schedules were extracted, fall back to manual parsing
-			if len(schedules) == 0 {
-				log.Printf("No schedules extracted from PDF. Using fallback manual schedule.")
-				schedules = parser.ParseScheduleManually()
-			} else {
-				log.Printf("Successfully extracted %d match schedules from PDF", len(schedules))
-			}
+	// Weight season averages the way the league does
+	weighting, err := stats.ParseWeighting(*weightingFlag)
+	if err != nil {
+		log.Fatalf("Invalid -weighting: %v", err)
+	}
+	stats.AverageWeighting = weighting
+
+	// Season segments for pages that don't state their own
+	segments, err := stats.ParseSegments(*segmentsFlag)
+	if err != nil {
+		log.Fatalf("Invalid -segments: %v", err)
+	}
+
+	// Load any export profiles for external tools
+	if *exportProfilesFlag != "" {
+		if err := utils.LoadExportProfiles(*exportProfilesFlag); err != nil {
+			log.Fatalf("Failed to load export profiles: %v", err)
 		}
 	}
 
-	// Base URL for the standings page
-	urls := []string{
-		"https://macdleagues.com/DartStandings/FALL2024standings/FALL2024%2024SUN1OZCounty.html",
+	// Use another league's team names if given
+	if *teamAliasesFlag != "" {
+		aliases, err := parser.LoadTeamAliases(*teamAliasesFlag)
+		if err != nil {
+			log.Fatalf("Failed to load team aliases: %v", err)
+		}
+		parser.SetTeamAliases(aliases)
 	}
-	log.Printf("Will scrape %d URLs", len(urls))
 
-	// Process each URL
-	var allWeeklyStats []*models.WeeklyStats
+	// Teams and players left out of all output
+	exclusions := stats.NewExclusions(splitList(*excludeTeamsFlag), splitList(*excludePlayersFlag))
 
-	for i, url := range urls {
-		log.Printf("Processing URL %d of %d: %s", i+1, len(urls), url)
+	// Match week numbers using the site's URL conventions
+	if len(weekPatterns) > 0 {
+		scraper.WeekPatterns = weekPatterns
+	}
 
-		// Download and extract standings links
-		htmlContent, err := scraper.FetchURL(url)
+	// Correct players the standings list under the wrong team
+	for _, override := range playerTeams {
+		name, team, found := strings.Cut(override, "=")
+		if !found || strings.TrimSpace(name) == "" || strings.TrimSpace(team) == "" {
+			log.Fatalf("Invalid -player-team %q: expected NAME=TEAM", override)
+		}
+		name = strings.ToUpper(strings.Join(strings.Fields(name), " "))
+		parser.PlayerTeamOverrides[name] = strings.TrimSpace(team)
+	}
+
+	// Select standings links for the requested season
+	scraper.SeasonPrefix = *seasonFlag
+	if *linkPatternFlag != "" {
+		pattern, err := regexp.Compile(*linkPatternFlag)
 		if err != nil {
-			log.Printf("Error scraping URL: %v", err)
-			continue
+			log.Fatalf("Invalid -link-pattern: %v", err)
+		}
+		scraper.StandingsLinkPattern = pattern
+	}
+
+	// Be polite to the league site
+	scraper.SetRequestInterval(*requestIntervalFlag)
+	scraper.SetHTTPTimeout(*httpTimeoutFlag)
+	if *proxyFlag != "" {
+		if err := scraper.SetProxy(*proxyFlag); err != nil {
+			log.Fatalf("Invalid -proxy: %v", err)
 		}
+	}
 
-		// Save the main index page HTML
-		indexHTMLPath := filepath.Join(htmlDir, fmt.Sprintf("index_%d.html", i+1))
-		if err := scraper.SaveContentToFile(indexHTMLPath, htmlContent); err != nil {
-			log.Printf("Error saving index HTML: %v", err)
-		} else {
-			log.Printf("Saved index HTML to %s", indexHTMLPath)
+	// Identify the scraper to the league site
+	scraper.UserAgent = *userAgentFlag
+	for _, header := range extraHeaders {
+		name, value, found := strings.Cut(header, ":")
+		if !found || strings.TrimSpace(name) == "" {
+			log.Fatalf("Invalid -header %q: expected \"Name: value\"", header)
+		}
+		scraper.ExtraHeaders.Add(strings.TrimSpace(name), strings.TrimSpace(value))
+	}
+
+	// Recognize the site's "page not found" template
+	scraper.SoftNotFoundMarkers = softNotFound
+
+	// Keep a copy of every fetched page when asked, and serve only those copies
+	// when offline
+	if *cacheFlag || *offlineFlag {
+		scraper.CacheDir = filepath.Join(outputDir, "cache")
+	}
+	scraper.Offline = *offlineFlag
+	if scraper.Offline {
+		log.Println("Offline mode: using cached pages only")
+	}
+
+	// PDF schedule URL
+	scheduleURL := *scheduleURLFlag
+	localPDFPath := filepath.Join(pdfDir, "fall2024_schedule.pdf")
+	if scheduleURL != defaultScheduleURL {
+		localPDFPath = filepath.Join(pdfDir, scraper.ScheduleFilename(scheduleURL))
+	}
+
+	// Let a hand-corrected schedule override the parsed one
+	var correctedSchedules []models.MatchSchedule
+	if *scheduleCSVFlag != "" {
+		correctedSchedules, err = utils.LoadScheduleFromCSV(*scheduleCSVFlag)
+		if err != nil {
+			log.Fatalf("Failed to load schedule CSV: %v", err)
 		}
+		log.Printf("Loaded %d corrected match schedules from %s", len(correctedSchedules), *scheduleCSVFlag)
+	}
+
+	// Standings index pages to scrape
+	urls := append([]string(nil), urlFlags...)
+	if *urlFileFlag != "" {
+		fileURLs, err := readURLFile(*urlFileFlag)
+		if err != nil {
+			log.Fatalf("Failed to read -url-file: %v", err)
+		}
+		urls = append(urls, fileURLs...)
+	}
+	if len(urls) == 0 {
+		urls = []string{defaultStandingsURL}
+	}
+	log.Printf("Will scrape %d URLs", len(urls))
 
-		log.Println("Extracting standings links...")
-		standingsLinks := scraper.ExtractStandingsLinks(htmlContent)
+	seasonConfig := app.Config{
+		URLs:               urls,
+		ScheduleURL:        scheduleURL,
+		SchedulePath:       localPDFPath,
+		CorrectedSchedules: correctedSchedules,
+		OutputDir:          outputDir,
+		CrawlDepth:         *crawlDepthFlag,
+		Weeks:              weekSet,
+		Since:              since,
+		LatestOnly:         *latestOnlyFlag,
+		CurrentWeek:        *currentWeekFlag,
+		Exclusions:         exclusions,
+		Segments:           segments,
+	}
 
-		// Convert relative links to absolute URLs
-		var standingsURLs []string
-		for _, link := range standingsLinks {
-			absURL := scraper.ResolveRelativeURL(url, link)
-			standingsURLs = append(standingsURLs, absURL)
+	// Each division keeps its stats in its own part of the store
+	divisionStores := make(map[string]storage.Store)
+	storeFor := func(division string) storage.Store {
+		if store == nil {
+			return nil
+		}
+		if divisionStore, found := divisionStores[division]; found {
+			return divisionStore
+		}
+		divisionStore, err := store.Division(division)
+		if err != nil {
+			log.Fatalf("Failed to open store for division %s: %v", division, err)
 		}
+		divisionStores[division] = divisionStore
+		return divisionStore
+	}
 
-		log.Printf("Found %d standings links to process", len(standingsURLs))
+	// Find where the previous runs stopped
+	if *latestOnlyFlag {
+		seasonConfig.StoredMaxWeeks = make(map[string]int)
+		for _, indexURL := range urls {
+			division := seasonConfig.Division(indexURL)
+			storedMaxWeek, err := storage.MaxWeek(storeFor(division))
+			if err != nil {
+				log.Fatalf("Failed to read stored weeks: %v", err)
+			}
+			seasonConfig.StoredMaxWeeks[division] = storedMaxWeek
+			log.Printf("Latest stored week for %s: %d", indexURL, storedMaxWeek)
+		}
+	}
 
-		// Process each standings page
-		for j, standingsURL := range standingsURLs {
-			// Extract the week number from the URL
-			week := j + 1 // Default: sequential weeks
-			extractedWeek := scraper.ExtractWeekNumber(standingsURL)
-			if extractedWeek > 0 {
-				week = extractedWeek
+	// Scrape the season, displaying and saving each week as it is parsed
+	divisionWeeks := make(map[string][]*models.WeeklyStats)
+	seasonConfig.OnWeek = func(indexURL string, scrapedStats *models.WeeklyStats) {
+		week := scrapedStats.Week
+		division := scrapedStats.Division
+		divisionWeeks[app.DivisionName(indexURL)] = append(divisionWeeks[app.DivisionName(indexURL)], scrapedStats)
+		weekStore := storeFor(division)
+
+		// Show and export only qualified players, but store everyone
+		weeklyStats := qualifiedPlayers(scrapedStats, *minGamesFlag)
+
+		// Display the stats for this week, or only what changed since the last run
+		if *formatFlag != formatTable {
+			log.Printf("Skipping display for week %d (format is %s)", week, *formatFlag)
+		} else if *currentWeekFlag > 0 && week != *currentWeekFlag {
+			log.Printf("Skipping display for week %d (current week is %d)", week, *currentWeekFlag)
+		} else if *changedOnlyFlag {
+			previous, err := weekStore.LoadWeek(week)
+			if err != nil && !errors.Is(err, storage.ErrWeekNotFound) {
+				log.Printf("Error loading previous stats for week %d: %v", week, err)
 			}
+			utils.DisplayPlayerChanges(week, stats.DiffWeeklyStats(qualifiedPlayers(previous, *minGamesFlag), weeklyStats))
+		} else {
+			utils.DisplayWeeklyStatsSorted(weeklyStats, sortKey, sortKey != utils.SortName)
+		}
+		if *boxScoreFlag > 0 && (*currentWeekFlag == 0 || week == *currentWeekFlag) {
+			fmt.Print(utils.RenderBoxScore(weeklyStats, *boxScoreFlag))
+		}
 
-			log.Printf("Processing standings for Week %d: %s", week, standingsURL)
+		// Remember this week's stats for the next run
+		if weekStore != nil {
+			if err := weekStore.SaveWeeklyStats(scrapedStats); err != nil {
+				log.Printf("Error storing stats for week %d: %v", week, err)
+			}
+		}
 
-			// Define the local HTML file path
-			localFilename := filepath.Join(htmlDir, fmt.Sprintf("standings_week_%d.html", week))
-			var weeklyStats *models.WeeklyStats
-			var htmlContent string
+		// Save in the requested format
+		if *formatFlag == formatJSON {
+			jsonFilename := filepath.Join(divisionDir(jsonDir, division), fmt.Sprintf("player_stats_week_%d.json", week))
+			if err := utils.SaveWeeklyStatsToJSON(weeklyStats, jsonFilename); err != nil {
+				log.Printf("Error saving JSON file: %v", err)
+			} else {
+				log.Printf("Saved player stats for week %d to %s", week, jsonFilename)
+			}
+		} else {
+			csvFilename := filepath.Join(divisionDir(csvDir, division), fmt.Sprintf("player_stats_week_%d.csv", week))
+			if err := utils.SaveWeeklyStatsToCSV(weeklyStats, csvFilename); err != nil {
+				log.Printf("Error saving CSV file: %v", err)
+			} else {
+				log.Printf("Saved player stats for week %d to %s", week, csvFilename)
+			}
+		}
 
-			// Try to use existing HTML file if available
-			if fileContent, err := os.ReadFile(localFilename); err == nil {
-				log.Printf("Using existing HTML file for week %d: %s", week, localFilename)
-				htmlContent = string(fileContent)
+		// Save a Markdown page for publishing
+		if *markdownFlag {
+			markdownFilename := filepath.Join(divisionDir(markdownDir, division), fmt.Sprintf("week_%d.md", week))
+			if err := utils.SaveWeeklyStatsToMarkdown(weeklyStats, markdownFilename); err != nil {
+				log.Printf("Error saving Markdown: %v", err)
 			} else {
-				// Download the HTML content if we don't have it locally
-				log.Printf("Downloading HTML for week %d from %s", week, standingsURL)
-				content, err := scraper.FetchURL(standingsURL)
-				if err != nil {
-					log.Printf("Error downloading standings page: %v", err)
-					continue
+				log.Printf("Saved Markdown for week %d to %s", week, markdownFilename)
+			}
+		}
+
+		// Save an interactive page for publishing
+		if *sortableHTMLFlag {
+			pageFilename := filepath.Join(divisionDir(htmlDir, division), fmt.Sprintf("sortable_week_%d.html", week))
+			if err := utils.SaveWeeklyStatsToSortableHTML(weeklyStats, pageFilename); err != nil {
+				log.Printf("Error saving sortable HTML: %v", err)
+			} else {
+				log.Printf("Saved sortable HTML for week %d to %s", week, pageFilename)
+			}
+		}
+
+		// Save again in the layout of the requested export profile
+		if *exportProfileFlag != "" {
+			profileFilename := filepath.Join(divisionDir(csvDir, division), fmt.Sprintf("%s_week_%d.csv", *exportProfileFlag, week))
+			if err := utils.SaveWeeklyStatsWithProfile(weeklyStats, *exportProfileFlag, profileFilename); err != nil {
+				log.Printf("Error saving %s export: %v", *exportProfileFlag, err)
+			} else {
+				log.Printf("Saved %s export for week %d to %s", *exportProfileFlag, week, profileFilename)
+			}
+		}
+	}
+	allWeeklyStats, schedules, err := app.ScrapeSeason(seasonConfig)
+	if err != nil {
+		log.Printf("Error scraping season: %v", err)
+	}
+
+	currentWeek := stats.CurrentWeek(allWeeklyStats, *currentWeekFlag)
+	log.Printf("Current week: %d", currentWeek)
+
+	// Compare the best players across divisions
+	if *compareTopFlag > 0 {
+		divisions := make(map[string][]models.PlayerSeasonStat)
+		for division, weeks := range divisionWeeks {
+			divisions[division] = stats.SeasonTotals(weeks)
+		}
+		utils.DisplayDivisionComparison(*compareMetricFlag, stats.CompareDivisions(divisions, *compareMetricFlag, *compareTopFlag))
+	}
+
+	// Rank the current week's players across all teams
+	if *leaderboardFlag > 0 {
+		var weekPlayers []models.PlayerStat
+		for _, weeklyStats := range allWeeklyStats {
+			if weeklyStats.Week == currentWeek {
+				weekPlayers = append(weekPlayers, stats.FilterByMinGames(weeklyStats.PlayerStats, *minGamesFlag)...)
+			}
+		}
+		utils.DisplayLeaderboard(fmt.Sprintf("Week %d top PPD", currentWeek), stats.TopPlayersByPPD(weekPlayers, *leaderboardFlag))
+		utils.DisplayLeaderboard(fmt.Sprintf("Week %d top MPR", currentWeek), stats.TopPlayersByMPR(weekPlayers, *leaderboardFlag))
+		if scores := stats.TopScores(weekPlayers, *leaderboardFlag); len(scores) > 0 {
+			utils.DisplayTopScores(fmt.Sprintf("Week %d top high scores", currentWeek), scores)
+		}
+		if checkouts := stats.TopCheckouts(weekPlayers, *leaderboardFlag); len(checkouts) > 0 {
+			utils.DisplayTopCheckouts(fmt.Sprintf("Week %d top checkouts", currentWeek), checkouts)
+		}
+	}
+
+	// Compare each division's current week with the week before
+	if *mostImprovedFlag > 0 {
+		for _, weeks := range divisionWeeks {
+			var prev, curr *models.WeeklyStats
+			for _, weeklyStats := range weeks {
+				switch weeklyStats.Week {
+				case currentWeek - 1:
+					prev = weeklyStats
+				case currentWeek:
+					curr = weeklyStats
 				}
+			}
+			utils.DisplayMostImproved(currentWeek, stats.MostImproved(prev, curr, *mostImprovedFlag))
+		}
+	}
 
-				// Save the downloaded HTML content
-				htmlContent = content
-				if err := scraper.SaveContentToFile(localFilename, htmlContent); err != nil {
-					log.Printf("Error saving standings HTML: %v", err)
-				} else {
-					log.Printf("Saved standings HTML for week %d to %s", week, localFilename)
+	// Save every week to one file for spreadsheets
+	if *allWeeksCSVFlag {
+		// An incremental run only scraped the new weeks, so take the rest from the store
+		combinedWeeks := allWeeklyStats
+		if *latestOnlyFlag {
+			combinedWeeks = nil
+			for _, indexURL := range urls {
+				storedWeeks, err := storage.LoadAll(storeFor(seasonConfig.Division(indexURL)))
+				if err != nil {
+					log.Printf("Error loading stored weeks: %v", err)
+					continue
 				}
+				combinedWeeks = append(combinedWeeks, storedWeeks...)
 			}
+		}
+		allWeeksFilename := filepath.Join(csvDir, "all_weeks.csv")
+		if err := utils.SaveAllWeeksToCSV(combinedWeeks, allWeeksFilename); err != nil {
+			log.Printf("Error saving all-weeks CSV: %v", err)
+		} else {
+			log.Printf("Saved all weeks to %s", allWeeksFilename)
+		}
+	}
 
-			// Extract player and team stats from the HTML content
-			playerStats, teamStats := parser.ExtractPlayerStats(htmlContent)
+	// Publish the weekly results as a feed
+	if *feedFlag {
+		feedFilename := filepath.Join(outputDir, "feed.xml")
+		feedConfig := utils.FeedConfig{Title: *feedTitleFlag, Link: urls[0]}
+		if err := utils.SaveWeeklyFeed(allWeeklyStats, feedConfig, feedFilename); err != nil {
+			log.Printf("Error saving feed: %v", err)
+		} else {
+			log.Printf("Saved feed to %s", feedFilename)
+		}
+	}
 
-			// Add opponent information to each player
-			for i := range playerStats {
-				opponent := parser.FindOpponent(playerStats[i].Team, week, schedules)
-				playerStats[i].Opponent = opponent
-			}
+	// Save the season dataset for analysis tools
+	if *parquetFlag {
+		parquetFilename := filepath.Join(outputDir, "season.parquet")
+		if err := parquetexport.SaveSeasonToParquet(allWeeklyStats, parquetFilename); err != nil {
+			log.Printf("Error saving Parquet file: %v", err)
+		} else {
+			log.Printf("Saved season to %s", parquetFilename)
+		}
+	}
+
+	// Save the schedule alongside the weekly stats
+	scheduleCSV := filepath.Join(csvDir, "schedule.csv")
+	if err := utils.SaveScheduleToCSV(exclusions.FilterSchedules(stats.ApplyMatchScores(allWeeklyStats, schedules)), scheduleCSV); err != nil {
+		log.Printf("Error saving schedule CSV: %v", err)
+	} else {
+		log.Printf("Saved schedule to %s", scheduleCSV)
+	}
+
+	// List what a real run would have written
+	if overlay, ok := utils.OutputFS.(*vfs.OverlayFS); ok {
+		for _, file := range overlay.Files() {
+			log.Printf("Dry run: skipped writing %s", file)
+		}
+	}
+
+	log.Println("Scraping complete")
+}
 
-			// Create the weekly stats object
-			weeklyStats = &models.WeeklyStats{
-				Week:        week,
-				PlayerStats: playerStats,
-				TeamStats:   teamStats,
+// divisionDir returns the subdirectory of dir a division's files are saved
+// in, creating it if needed, or dir itself for the empty division
+func divisionDir(dir, division string) string {
+	if division == "" {
+		return dir
+	}
+	subdir := filepath.Join(dir, division)
+	if err := utils.OutputFS.MkdirAll(subdir, 0755); err != nil {
+		log.Printf("Error creating directory %s: %v", subdir, err)
+	}
+	return subdir
+}
+
+// qualifiedPlayers returns a copy of the week without players who played
+// fewer than minGames games, or the week itself when minGames is not positive
+func qualifiedPlayers(weeklyStats *models.WeeklyStats, minGames int) *models.WeeklyStats {
+	if weeklyStats == nil || minGames <= 0 {
+		return weeklyStats
+	}
+	filtered := *weeklyStats
+	filtered.PlayerStats = stats.FilterByMinGames(weeklyStats.PlayerStats, minGames)
+	return &filtered
+}
+
+// openStore opens the SQLite database at dbPath, or the file store in the
+// output directory when dbPath is empty, and returns a function closing it
+func openStore(dbPath, outputDir string) (storage.Store, func(), error) {
+	if dbPath != "" {
+		db, err := storage.OpenDB(dbPath)
+		if err != nil {
+			return nil, nil, err
+		}
+		return db, func() {
+			if err := db.Close(); err != nil {
+				log.Printf("Error closing database: %v", err)
 			}
+		}, nil
+	}
 
-			// Add to weekly stats collection
-			allWeeklyStats = append(allWeeklyStats, weeklyStats)
+	store, err := storage.NewFileStoreFS(utils.OutputFS, filepath.Join(outputDir, "store"))
+	if err != nil {
+		return nil, nil, err
+	}
+	return store, func() {}, nil
+}
 
-			// Display the stats for this week with opponent information
-			utils.DisplayWeeklyStatsWithOpponents(weeklyStats)
+// readURLFile reads one URL per line, skipping blank lines and # comments
+func readURLFile(filename string) ([]string, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
 
-			// Save to CSV
-			csvFilename := filepath.Join(csvDir, fmt.Sprintf("player_stats_week_%d.csv", week))
-			err = utils.SaveWeeklyStatsToCSV(weeklyStats, csvFilename)
-			if err != nil {
-				log.Printf("Error saving CSV file: %v", err)
-			} else {
-				log.Printf("Saved player stats for week %d to %s", week, csvFilename)
+	var urls []string
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		urls = append(urls, line)
+	}
+	return urls, nil
+}
+
+// parseWeekSpec parses a list of weeks and week ranges such as "10-12" or
+// "3,5,7-9" into a set. An empty spec returns nil, meaning every week.
+func parseWeekSpec(spec string) (map[int]bool, error) {
+	if strings.TrimSpace(spec) == "" {
+		return nil, nil
+	}
+
+	weeks := make(map[int]bool)
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		first, last, isRange := strings.Cut(part, "-")
+
+		from, err := strconv.Atoi(strings.TrimSpace(first))
+		if err != nil || from < 1 {
+			return nil, fmt.Errorf("%q is not a week or range of weeks", part)
+		}
+		to := from
+		if isRange {
+			to, err = strconv.Atoi(strings.TrimSpace(last))
+			if err != nil || to < from {
+				return nil, fmt.Errorf("%q is not a valid range of weeks", part)
 			}
 		}
+
+		for week := from; week <= to; week++ {
+			weeks[week] = true
+		}
 	}
+	return weeks, nil
+}
 
-	log.Println("Scraping complete")
+// stringList is a flag that can be given several times
+type stringList []string
+
+func (l *stringList) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *stringList) Set(value string) error {
+	*l = append(*l, value)
+	return nil
+}
+
+// splitList splits a comma-separated flag value, ignoring empty entries
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
 }
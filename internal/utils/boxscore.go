@@ -0,0 +1,65 @@
+package utils
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/myusername/dart-statistic-scraper/pkg/models"
+	"github.com/myusername/dart-statistic-scraper/pkg/stats"
+)
+
+// boxScoreWidth keeps box scores narrow enough for a phone screen
+const boxScoreWidth = 34
+
+// RenderBoxScore renders a compact plain-text box score for each team: the team
+// name, its top topN players by PPD (all players when topN <= 0) and the team
+// totals. Lines stay under 40 characters so the block can be pasted into a chat.
+func RenderBoxScore(weeklyStats *models.WeeklyStats, topN int) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "WEEK %d", weeklyStats.Week)
+	if weeklyStats.Date != "" {
+		fmt.Fprintf(&b, " - %s", weeklyStats.Date)
+	}
+	b.WriteString("\n")
+
+	// Group players by team
+	teamPlayers := make(map[string][]models.PlayerStat)
+	for _, player := range weeklyStats.PlayerStats {
+		teamPlayers[player.Team] = append(teamPlayers[player.Team], player)
+	}
+
+	var teamNames []string
+	for team := range teamPlayers {
+		teamNames = append(teamNames, team)
+	}
+	sort.Strings(teamNames)
+
+	for _, team := range teamNames {
+		players := teamPlayers[team]
+		sort.SliceStable(players, func(i, j int) bool {
+			return players[i].PPD > players[j].PPD
+		})
+		if topN > 0 && len(players) > topN {
+			players = players[:topN]
+		}
+
+		name := team
+		if name == "" {
+			name = "(no team)"
+		}
+		fmt.Fprintf(&b, "\n%.*s\n", boxScoreWidth, strings.ToUpper(name))
+		b.WriteString(strings.Repeat("-", boxScoreWidth) + "\n")
+		fmt.Fprintf(&b, "%-18s %3s %5s %5s\n", "Player", "G", "PPD", "MPR")
+		for _, player := range players {
+			fmt.Fprintf(&b, "%-18.18s %3d %5.2f %5.2f\n", player.PlayerName, player.GamesPlayed, player.PPD, player.MPR)
+		}
+
+		if totals, found := stats.TeamWeekTotals(weeklyStats, team); found {
+			fmt.Fprintf(&b, "%-18s %3d %5.2f %5.2f\n", "Totals", totals.GamesPlayed, totals.PPD, totals.MPR)
+			fmt.Fprintf(&b, "Won %d of %d\n", totals.GamesWon, totals.GamesPlayed)
+		}
+	}
+
+	return b.String()
+}
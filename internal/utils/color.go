@@ -0,0 +1,82 @@
+package utils
+
+import (
+	"os"
+
+	"github.com/myusername/dart-statistic-scraper/pkg/models"
+)
+
+// ANSI escape sequences used for console output
+const (
+	ansiReset = "\033[0m"
+	ansiBold  = "\033[1;32m"
+	ansiDim   = "\033[2m"
+)
+
+// ColorThresholds controls how player rows are colorized relative to their
+// team's games-weighted average PPD. The top performer on each team is always
+// highlighted.
+type ColorThresholds struct {
+	// HighlightAbove also highlights players whose PPD is at least this multiple
+	// of the team average; 0 highlights only the top performer
+	HighlightAbove float64
+	// DimBelow dims players whose PPD is below this multiple of the team average
+	DimBelow float64
+}
+
+// ColorOutput enables ANSI colors in the console tables. It defaults to on only
+// when stdout is a terminal and NO_COLOR is not set.
+var ColorOutput = colorSupported()
+
+// Colors holds the thresholds used when ColorOutput is enabled
+var Colors = ColorThresholds{
+	DimBelow: 0.9,
+}
+
+// colorSupported reports whether stdout is a terminal that should get colors
+func colorSupported() bool {
+	if _, set := os.LookupEnv("NO_COLOR"); set {
+		return false
+	}
+	info, err := os.Stdout.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// teamAveragePPD returns the games-weighted PPD of a team's players
+func teamAveragePPD(players []models.PlayerStat) float64 {
+	var ppdTotal float64
+	games := 0
+	for _, player := range players {
+		ppdTotal += player.PPD * float64(player.GamesPlayed)
+		games += player.GamesPlayed
+	}
+	if games == 0 {
+		return 0
+	}
+	return ppdTotal / float64(games)
+}
+
+// colorizeRow wraps a player's row in the color for their performance.
+// isTop marks the team's top performer.
+func colorizeRow(row string, player models.PlayerStat, teamAverage float64, isTop bool) string {
+	if !ColorOutput {
+		return row
+	}
+
+	switch {
+	case player.GamesPlayed == 0:
+		return ansiDim + row + ansiReset
+	case isTop && player.PPD > 0:
+		return ansiBold + row + ansiReset
+	case teamAverage <= 0:
+		return row
+	case Colors.HighlightAbove > 0 && player.PPD >= teamAverage*Colors.HighlightAbove:
+		return ansiBold + row + ansiReset
+	case player.PPD < teamAverage*Colors.DimBelow:
+		return ansiDim + row + ansiReset
+	}
+	return row
+}
@@ -2,24 +2,109 @@
 package utils
 
 import (
+	"encoding/csv"
+	"encoding/json"
 	"fmt"
 	"os"
 	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/myusername/dart-statistic-scraper/pkg/models"
+	"github.com/myusername/dart-statistic-scraper/pkg/parser"
+	"github.com/myusername/dart-statistic-scraper/pkg/stats"
+	"github.com/myusername/dart-statistic-scraper/pkg/vfs"
 )
 
+// OutputFS is the filesystem the save functions write to
+var OutputFS vfs.FS = vfs.OS{}
+
 // DisplayWeeklyStatsWithOpponents prints the player statistics for a given week including opponent information
 func DisplayWeeklyStatsWithOpponents(weeklyStats *models.WeeklyStats) {
+	DisplayWeeklyStatsSorted(weeklyStats, SortPPD, true)
+}
+
+// SortKey selects how players are ordered within each team
+type SortKey int
+
+// Supported sort keys
+const (
+	SortPPD SortKey = iota
+	SortMPR
+	SortGamesWon
+	SortName
+	SortWinPct
+)
+
+// ParseSortKey reads a sort key name: "ppd", "mpr", "wins", "name" or "winpct"
+func ParseSortKey(name string) (SortKey, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "ppd", "":
+		return SortPPD, nil
+	case "mpr":
+		return SortMPR, nil
+	case "wins", "gameswon":
+		return SortGamesWon, nil
+	case "name":
+		return SortName, nil
+	case "winpct":
+		return SortWinPct, nil
+	}
+	return SortPPD, fmt.Errorf("unknown sort key %q", name)
+}
+
+// SortPlayers orders players by key, breaking ties by name
+func SortPlayers(players []models.PlayerStat, key SortKey, descending bool) {
+	sort.SliceStable(players, func(i, j int) bool {
+		a, b := players[i], players[j]
+		var less, greater bool
+		switch key {
+		case SortMPR:
+			less, greater = a.MPR < b.MPR, a.MPR > b.MPR
+		case SortGamesWon:
+			less, greater = a.GamesWon < b.GamesWon, a.GamesWon > b.GamesWon
+		case SortWinPct:
+			less, greater = a.WinPercentage() < b.WinPercentage(), a.WinPercentage() > b.WinPercentage()
+		case SortName:
+			less, greater = a.PlayerName < b.PlayerName, a.PlayerName > b.PlayerName
+		default:
+			less, greater = a.PPD < b.PPD, a.PPD > b.PPD
+		}
+		if less || greater {
+			if descending {
+				return greater
+			}
+			return less
+		}
+		return a.PlayerName < b.PlayerName
+	})
+}
+
+// DisplayWeeklyStatsSorted displays the weekly statistics like
+// DisplayWeeklyStatsWithOpponents, ordering each team's players by key
+func DisplayWeeklyStatsSorted(weeklyStats *models.WeeklyStats, key SortKey, descending bool) {
 	fmt.Printf("\n=========== PLAYER STATISTICS FOR WEEK %d ===========\n", weeklyStats.Week)
-	fmt.Printf("%-26s | %-6s | %-15s | %-5s | %-4s | %-6s | %-5s | %-3s | %-6s | %-6s\n",
-		"Player", "SancPd", "Opponent", "Games", "Wins", "PPD", "MPR", "Hat", "HstTon", "HstOut")
-	fmt.Printf("%-26s | %-6s | %-15s | %-5s | %-4s | %-6s | %-5s | %-3s | %-6s | %-6s\n",
+
+	// Drop the high score/checkout columns when the division doesn't track them
+	showHighScore := hasHighScores(weeklyStats.PlayerStats)
+	showHighCheckout := hasHighCheckouts(weeklyStats.PlayerStats)
+
+	header := fmt.Sprintf("%-26s | %-6s | %-15s | %-5s | %-4s | %-6s | %-5s | %-3s",
+		"Player", "SancPd", "Opponent", "Games", "Wins", "PPD", "MPR", "Hat")
+	divider := fmt.Sprintf("%-26s | %-6s | %-15s | %-5s | %-4s | %-6s | %-5s | %-3s",
 		strings.Repeat("-", 26), strings.Repeat("-", 6), strings.Repeat("-", 15),
 		strings.Repeat("-", 5), strings.Repeat("-", 4), strings.Repeat("-", 6),
-		strings.Repeat("-", 5), strings.Repeat("-", 3), strings.Repeat("-", 6),
-		strings.Repeat("-", 6))
+		strings.Repeat("-", 5), strings.Repeat("-", 3))
+	if showHighScore {
+		header += fmt.Sprintf(" | %-6s", "HstTon")
+		divider += " | " + strings.Repeat("-", 6)
+	}
+	if showHighCheckout {
+		header += fmt.Sprintf(" | %-6s", "HstOut")
+		divider += " | " + strings.Repeat("-", 6)
+	}
+	fmt.Println(header)
+	fmt.Println(divider)
 
 	// Group players by team
 	teamPlayers := make(map[string][]models.PlayerStat)
@@ -34,51 +119,104 @@ func DisplayWeeklyStatsWithOpponents(weeklyStats *models.WeeklyStats) {
 	}
 	sort.Strings(teamNames)
 
-	// Print players by team, sorted by PPD within each team
+	// Print players by team, sorted by key within each team
 	for _, team := range teamNames {
 		players := teamPlayers[team]
-
-		// Sort players by PPD (descending)
-		sort.Slice(players, func(i, j int) bool {
-			return players[i].PPD > players[j].PPD
-		})
+		SortPlayers(players, key, descending)
 
 		// Print team name
 		if team != "" {
 			fmt.Printf("\n%s\n", team)
 		}
 
-		// Print player stats
-		for _, player := range players {
-			fmt.Printf("%-26s | %-6s | %-15s | %5d | %4d | %6.2f | %5.2f | %3d | %6d | %6d\n",
+		// Print player stats, highlighting the top performer by PPD
+		teamAverage := teamAveragePPD(players)
+		top := 0
+		for i, player := range players {
+			if player.PPD > players[top].PPD {
+				top = i
+			}
+		}
+		for i, player := range players {
+			row := fmt.Sprintf("%-26s | %-6s | %-15s | %5d | %4d | %6.2f | %5.2f | %3d",
 				player.PlayerName, player.SancPd, player.Opponent, player.GamesPlayed, player.GamesWon,
-				player.PPD, player.MPR, player.HatTricks, player.HighScore, player.HighCheckout)
+				player.PPD, player.MPR, player.HatTricks)
+			if showHighScore {
+				row += fmt.Sprintf(" | %6d", player.HighScore)
+			}
+			if showHighCheckout {
+				row += fmt.Sprintf(" | %6d", player.HighCheckout)
+			}
+			fmt.Println(colorizeRow(row, player, teamAverage, i == top))
 		}
 	}
 
 	fmt.Println(strings.Repeat("=", 78))
 }
 
+// DisplayPlayerChanges prints a compact list of players that are new or changed since the last run
+func DisplayPlayerChanges(week int, changes []stats.PlayerChange) {
+	fmt.Printf("\n=========== CHANGED PLAYERS FOR WEEK %d ===========\n", week)
+	if len(changes) == 0 {
+		fmt.Println("No changes since the last run")
+	}
+
+	for _, change := range changes {
+		player := change.Player
+		if change.Type == stats.ChangeNew {
+			fmt.Printf("+ %s (%s): %d games, %d wins, PPD %.2f, MPR %.2f\n",
+				player.PlayerName, player.Team, player.GamesPlayed, player.GamesWon, player.PPD, player.MPR)
+			continue
+		}
+
+		var fields []string
+		for _, field := range change.Changes {
+			fields = append(fields, fmt.Sprintf("%s %s -> %s", field.Field, field.Old, field.New))
+		}
+		fmt.Printf("~ %s (%s): %s\n", player.PlayerName, player.Team, strings.Join(fields, ", "))
+	}
+
+	fmt.Println(strings.Repeat("=", 78))
+}
+
+// DisplayDivisionComparison prints a combined leaderboard of players from several divisions
+func DisplayDivisionComparison(metric string, players []stats.DivisionPlayer) {
+	fmt.Printf("\n=========== TOP PLAYERS ACROSS DIVISIONS BY %s ===========\n", strings.ToUpper(metric))
+	fmt.Printf("%-4s | %-26s | %-20s | %-15s | %-5s | %-6s | %-5s\n",
+		"Rank", "Player", "Division", "Team", "Games", "PPD", "MPR")
+	fmt.Printf("%-4s | %-26s | %-20s | %-15s | %-5s | %-6s | %-5s\n",
+		strings.Repeat("-", 4), strings.Repeat("-", 26), strings.Repeat("-", 20),
+		strings.Repeat("-", 15), strings.Repeat("-", 5), strings.Repeat("-", 6), strings.Repeat("-", 5))
+
+	for i, player := range players {
+		fmt.Printf("%4d | %-26s | %-20s | %-15s | %5d | %6.2f | %5.2f\n",
+			i+1, player.PlayerName, player.Division, player.Team, player.GamesPlayed, player.PPD, player.MPR)
+	}
+
+	fmt.Println(strings.Repeat("=", 78))
+}
+
 // SaveWeeklyStatsToCSV saves the player statistics for a given week to a CSV file
 func SaveWeeklyStatsToCSV(weeklyStats *models.WeeklyStats, filename string) error {
-	f, err := os.Create(filename)
+	f, err := OutputFS.Create(filename)
 	if err != nil {
 		return fmt.Errorf("failed to create file: %w", err)
 	}
 	defer f.Close()
 
+	// Drop the high score/checkout columns when the division doesn't track them
+	showHighScore := hasHighScores(weeklyStats.PlayerStats)
+	showHighCheckout := hasHighCheckouts(weeklyStats.PlayerStats)
+
 	// Write CSV header
-	_, err = fmt.Fprintf(f, "Week,Player,Team,Opponent,SancPd,GamesPlayed,GamesWon,PPD,MPR,HatTricks,HighScore,HighCheckout\n")
+	_, err = fmt.Fprintln(f, statsCSVHeader(showHighScore, showHighCheckout))
 	if err != nil {
 		return fmt.Errorf("failed to write header: %w", err)
 	}
 
 	// Write player stats
 	for _, player := range weeklyStats.PlayerStats {
-		_, err = fmt.Fprintf(f, "%d,%s,%s,%s,%s,%d,%d,%.2f,%.2f,%d,%d,%d\n",
-			weeklyStats.Week, player.PlayerName, player.Team, player.Opponent, player.SancPd,
-			player.GamesPlayed, player.GamesWon, player.PPD, player.MPR, player.HatTricks,
-			player.HighScore, player.HighCheckout)
+		_, err = fmt.Fprintln(f, statsCSVRow(weeklyStats.Week, player, showHighScore, showHighCheckout))
 		if err != nil {
 			return fmt.Errorf("failed to write player data: %w", err)
 		}
@@ -86,3 +224,232 @@ func SaveWeeklyStatsToCSV(weeklyStats *models.WeeklyStats, filename string) erro
 
 	return nil
 }
+
+// SaveAllWeeksToCSV saves the player statistics of every week to a single CSV
+// file in the SaveWeeklyStatsToCSV layout, ordered by week, team and player
+func SaveAllWeeksToCSV(weeks []*models.WeeklyStats, filename string) error {
+	type weekRow struct {
+		week   int
+		player models.PlayerStat
+	}
+
+	var rows []weekRow
+	var allPlayers []models.PlayerStat
+	for _, weeklyStats := range weeks {
+		if weeklyStats == nil {
+			continue
+		}
+		for _, player := range weeklyStats.PlayerStats {
+			rows = append(rows, weekRow{weeklyStats.Week, player})
+		}
+		allPlayers = append(allPlayers, weeklyStats.PlayerStats...)
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		if rows[i].week != rows[j].week {
+			return rows[i].week < rows[j].week
+		}
+		if rows[i].player.Team != rows[j].player.Team {
+			return rows[i].player.Team < rows[j].player.Team
+		}
+		return rows[i].player.PlayerName < rows[j].player.PlayerName
+	})
+
+	f, err := OutputFS.Create(filename)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer f.Close()
+
+	// Keep the high score/checkout columns if any week tracks them
+	showHighScore := hasHighScores(allPlayers)
+	showHighCheckout := hasHighCheckouts(allPlayers)
+
+	if _, err := fmt.Fprintln(f, statsCSVHeader(showHighScore, showHighCheckout)); err != nil {
+		return fmt.Errorf("failed to write header: %w", err)
+	}
+	for _, row := range rows {
+		if _, err := fmt.Fprintln(f, statsCSVRow(row.week, row.player, showHighScore, showHighCheckout)); err != nil {
+			return fmt.Errorf("failed to write player data: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// statsCSVHeader returns the header line of a player stats CSV file
+func statsCSVHeader(showHighScore, showHighCheckout bool) string {
+	header := "Week,Player,Team,Opponent,SancPd,GamesPlayed,GamesWon,PPD,MPR,HatTricks"
+	if showHighScore {
+		header += ",HighScore"
+	}
+	if showHighCheckout {
+		header += ",HighCheckout"
+	}
+	return header
+}
+
+// statsCSVRow returns a player's line in a player stats CSV file
+func statsCSVRow(week int, player models.PlayerStat, showHighScore, showHighCheckout bool) string {
+	row := fmt.Sprintf("%d,%s,%s,%s,%s,%d,%d,%.2f,%.2f,%d",
+		week, player.PlayerName, player.Team, player.Opponent, player.SancPd,
+		player.GamesPlayed, player.GamesWon, player.PPD, player.MPR, player.HatTricks)
+	if showHighScore {
+		row += fmt.Sprintf(",%d", player.HighScore)
+	}
+	if showHighCheckout {
+		row += fmt.Sprintf(",%d", player.HighCheckout)
+	}
+	return row
+}
+
+// SaveWeeklyStatsToJSON saves the statistics for a given week to a JSON file,
+// recording the schema version alongside the stats
+func SaveWeeklyStatsToJSON(weeklyStats *models.WeeklyStats, filename string) error {
+	doc := struct {
+		SchemaVersion int `json:"schemaVersion"`
+		*models.WeeklyStats
+	}{models.SchemaVersion, weeklyStats}
+
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode week %d: %w", weeklyStats.Week, err)
+	}
+	if err := vfs.WriteFile(OutputFS, filename, data); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+	return nil
+}
+
+// SaveScheduleToCSV saves the season schedule to a CSV file, sorted by week then
+// home team. Mirror entries (the same pairing listed from both sides in the same
+// division) are written once.
+// Score columns are left blank for matches without a score.
+func SaveScheduleToCSV(schedules []models.MatchSchedule, filename string) error {
+	// Deduplicate matchups regardless of which team is listed first
+	seen := make(map[string]bool)
+	var matches []models.MatchSchedule
+	for _, schedule := range schedules {
+		home := parser.NormalizeTeamName(schedule.HomeTeam)
+		away := parser.NormalizeTeamName(schedule.AwayTeam)
+		if away < home {
+			home, away = away, home
+		}
+		key := fmt.Sprintf("%d|%s|%s|%s", schedule.Week, schedule.Division, home, away)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		matches = append(matches, schedule)
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].Week != matches[j].Week {
+			return matches[i].Week < matches[j].Week
+		}
+		return matches[i].HomeTeam < matches[j].HomeTeam
+	})
+
+	f, err := OutputFS.Create(filename)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer f.Close()
+
+	w := csv.NewWriter(f)
+	if err := w.Write([]string{"Week", "Date", "HomeTeam", "AwayTeam", "HomeScore", "AwayScore", "Division"}); err != nil {
+		return fmt.Errorf("failed to write header: %w", err)
+	}
+
+	for _, match := range matches {
+		record := []string{strconv.Itoa(match.Week), match.Date, match.HomeTeam, match.AwayTeam, "", "", match.Division}
+		if match.HasScore {
+			record[4] = strconv.Itoa(match.HomeScore)
+			record[5] = strconv.Itoa(match.AwayScore)
+		}
+		if err := w.Write(record); err != nil {
+			return fmt.Errorf("failed to write schedule data: %w", err)
+		}
+	}
+
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return fmt.Errorf("failed to write schedule data: %w", err)
+	}
+	return nil
+}
+
+// LoadScheduleFromCSV reads a schedule written by SaveScheduleToCSV, or a
+// hand-edited file with the same Week,Date,HomeTeam,AwayTeam columns. Score
+// and Division columns are optional.
+func LoadScheduleFromCSV(filename string) ([]models.MatchSchedule, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
+	defer f.Close()
+
+	r := csv.NewReader(f)
+	r.FieldsPerRecord = -1
+	records, err := r.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read schedule: %w", err)
+	}
+
+	var schedules []models.MatchSchedule
+	for i, record := range records {
+		// Skip the header row
+		if i == 0 && len(record) > 0 && strings.EqualFold(strings.TrimSpace(record[0]), "Week") {
+			continue
+		}
+		if len(record) < 4 {
+			return nil, fmt.Errorf("line %d: expected at least 4 columns, got %d", i+1, len(record))
+		}
+
+		week, err := strconv.Atoi(strings.TrimSpace(record[0]))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid week %q: %w", i+1, record[0], err)
+		}
+		match := models.MatchSchedule{
+			Week:     week,
+			Date:     strings.TrimSpace(record[1]),
+			HomeTeam: strings.TrimSpace(record[2]),
+			AwayTeam: strings.TrimSpace(record[3]),
+		}
+		if parsedDate, err := parser.ParseMatchDate(match.Date); err == nil {
+			match.ParsedDate = parsedDate
+		}
+
+		if len(record) >= 6 {
+			homeScore, homeErr := strconv.Atoi(strings.TrimSpace(record[4]))
+			awayScore, awayErr := strconv.Atoi(strings.TrimSpace(record[5]))
+			if homeErr == nil && awayErr == nil {
+				match.HomeScore, match.AwayScore, match.HasScore = homeScore, awayScore, true
+			}
+		}
+		if len(record) >= 7 {
+			match.Division = strings.TrimSpace(record[6])
+		}
+		schedules = append(schedules, match)
+	}
+	return schedules, nil
+}
+
+// hasHighScores reports whether any player has a high score recorded
+func hasHighScores(players []models.PlayerStat) bool {
+	for _, player := range players {
+		if player.HighScore != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// hasHighCheckouts reports whether any player has a high checkout recorded
+func hasHighCheckouts(players []models.PlayerStat) bool {
+	for _, player := range players {
+		if player.HighCheckout != 0 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,103 @@
+package utils
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/myusername/dart-statistic-scraper/pkg/models"
+	"github.com/myusername/dart-statistic-scraper/pkg/vfs"
+)
+
+func TestSortPlayers(t *testing.T) {
+	players := []models.PlayerStat{
+		{PlayerName: "CAL", GamesPlayed: 10, GamesWon: 5, PPD: 22, MPR: 2.5},
+		{PlayerName: "AMY", GamesPlayed: 4, GamesWon: 3, PPD: 25, MPR: 1.5},
+		{PlayerName: "BOB", GamesPlayed: 10, GamesWon: 8, PPD: 18, MPR: 2.5},
+		{PlayerName: "DAN", GamesPlayed: 0, GamesWon: 0, PPD: 0, MPR: 0},
+	}
+
+	tests := []struct {
+		key        SortKey
+		descending bool
+		want       []string
+	}{
+		{SortPPD, true, []string{"AMY", "CAL", "BOB", "DAN"}},
+		{SortPPD, false, []string{"DAN", "BOB", "CAL", "AMY"}},
+		// Ties are broken by name either way
+		{SortMPR, true, []string{"BOB", "CAL", "AMY", "DAN"}},
+		{SortMPR, false, []string{"DAN", "AMY", "BOB", "CAL"}},
+		{SortGamesWon, true, []string{"BOB", "CAL", "AMY", "DAN"}},
+		{SortName, false, []string{"AMY", "BOB", "CAL", "DAN"}},
+		{SortName, true, []string{"DAN", "CAL", "BOB", "AMY"}},
+		{SortWinPct, true, []string{"BOB", "AMY", "CAL", "DAN"}},
+	}
+
+	for _, tt := range tests {
+		sorted := append([]models.PlayerStat(nil), players...)
+		SortPlayers(sorted, tt.key, tt.descending)
+
+		var got []string
+		for _, player := range sorted {
+			got = append(got, player.PlayerName)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("SortPlayers(key %d, descending %v) = %v, want %v", tt.key, tt.descending, got, tt.want)
+		}
+	}
+}
+
+func TestParseSortKey(t *testing.T) {
+	for name, want := range map[string]SortKey{"": SortPPD, "PPD": SortPPD, "mpr": SortMPR, "wins": SortGamesWon, "name": SortName, "winpct": SortWinPct} {
+		if got, err := ParseSortKey(name); err != nil || got != want {
+			t.Errorf("ParseSortKey(%q) = %d, %v; want %d", name, got, err, want)
+		}
+	}
+	if _, err := ParseSortKey("height"); err == nil {
+		t.Error("ParseSortKey(height) succeeded")
+	}
+}
+
+// useMemoryOutput sends the files a test saves to memory
+func useMemoryOutput(t *testing.T) *vfs.MemFS {
+	t.Helper()
+	fsys := vfs.NewMemFS()
+	oldFS := OutputFS
+	OutputFS = fsys
+	t.Cleanup(func() { OutputFS = oldFS })
+	return fsys
+}
+
+func TestSaveAllWeeksToCSV(t *testing.T) {
+	fsys := useMemoryOutput(t)
+	weeks := []*models.WeeklyStats{
+		{Week: 2, PlayerStats: []models.PlayerStat{
+			{PlayerName: "MARY JONES", Team: "REDHEADS", Opponent: "HARBOR HILLS", GamesPlayed: 8, GamesWon: 3, PPD: 18.2},
+		}},
+		nil,
+		{Week: 1, PlayerStats: []models.PlayerStat{
+			{PlayerName: "TOM NG", Team: "REDHEADS", Opponent: "HARBOR HILLS", GamesPlayed: 9, GamesWon: 4, PPD: 20.1, MPR: 1.9},
+			{PlayerName: "JOHN SMITH", Team: "HARBOR HILLS", Opponent: "REDHEADS", SancPd: "A", GamesPlayed: 10, GamesWon: 6, PPD: 24.5, MPR: 2.1, HatTricks: 1},
+			{PlayerName: "MARY JONES", Team: "REDHEADS", Opponent: "HARBOR HILLS", GamesPlayed: 8, GamesWon: 4, PPD: 17.0},
+		}},
+	}
+
+	if err := SaveAllWeeksToCSV(weeks, "all_weeks.csv"); err != nil {
+		t.Fatal(err)
+	}
+	data, err := vfs.ReadFile(fsys, "all_weeks.csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		"Week,Player,Team,Opponent,SancPd,GamesPlayed,GamesWon,PPD,MPR,HatTricks",
+		"1,JOHN SMITH,HARBOR HILLS,REDHEADS,A,10,6,24.50,2.10,1",
+		"1,MARY JONES,REDHEADS,HARBOR HILLS,,8,4,17.00,0.00,0",
+		"1,TOM NG,REDHEADS,HARBOR HILLS,,9,4,20.10,1.90,0",
+		"2,MARY JONES,REDHEADS,HARBOR HILLS,,8,3,18.20,0.00,0",
+	}
+	if got := strings.Split(strings.TrimSpace(string(data)), "\n"); !reflect.DeepEqual(got, want) {
+		t.Errorf("all_weeks.csv =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
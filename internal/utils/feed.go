@@ -0,0 +1,186 @@
+package utils
+
+import (
+	"encoding/xml"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/myusername/dart-statistic-scraper/pkg/models"
+	"github.com/myusername/dart-statistic-scraper/pkg/parser"
+	"github.com/myusername/dart-statistic-scraper/pkg/stats"
+	"github.com/myusername/dart-statistic-scraper/pkg/vfs"
+)
+
+// FeedConfig describes the Atom feed written by GenerateWeeklyFeed
+type FeedConfig struct {
+	// Title of the feed, e.g. the league name
+	Title string
+	// Link is the league site the feed and its entries point to
+	Link string
+	// ID uniquely identifies the feed; Link is used when empty
+	ID string
+	// Author is named as the author of the feed
+	Author string
+	// Updated is used for weeks without a parseable date (default: now)
+	Updated time.Time
+}
+
+// atomFeed and its parts mirror the Atom elements the feed uses
+type atomFeed struct {
+	XMLName xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	Title   string      `xml:"title"`
+	ID      string      `xml:"id"`
+	Updated string      `xml:"updated"`
+	Link    *atomLink   `xml:"link,omitempty"`
+	Author  *atomAuthor `xml:"author,omitempty"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+}
+
+type atomAuthor struct {
+	Name string `xml:"name"`
+}
+
+type atomEntry struct {
+	Title   string    `xml:"title"`
+	ID      string    `xml:"id"`
+	Updated string    `xml:"updated"`
+	Link    *atomLink `xml:"link,omitempty"`
+	Summary string    `xml:"summary"`
+}
+
+// GenerateWeeklyFeed returns an Atom feed with an entry per week, newest
+// first, summarizing the week's top PPD player and best team record
+func GenerateWeeklyFeed(weeks []*models.WeeklyStats, cfg FeedConfig) (string, error) {
+	fallback := cfg.Updated
+	if fallback.IsZero() {
+		fallback = time.Now()
+	}
+
+	id := cfg.ID
+	if id == "" {
+		id = cfg.Link
+	}
+	if id == "" {
+		return "", fmt.Errorf("feed needs an ID or a link")
+	}
+
+	feed := atomFeed{Title: cfg.Title, ID: id}
+	if cfg.Link != "" {
+		feed.Link = &atomLink{Href: cfg.Link}
+	}
+	if cfg.Author != "" {
+		feed.Author = &atomAuthor{Name: cfg.Author}
+	}
+
+	// Newest week first
+	sorted := make([]*models.WeeklyStats, 0, len(weeks))
+	for _, weeklyStats := range weeks {
+		if weeklyStats != nil {
+			sorted = append(sorted, weeklyStats)
+		}
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Week > sorted[j].Week
+	})
+
+	var latest time.Time
+	weekEntries := make(map[int]int)
+	for _, weeklyStats := range sorted {
+		updated := fallback
+		if date, err := parser.ParseMatchDate(weeklyStats.Date); err == nil {
+			updated = date
+		}
+		if updated.After(latest) {
+			latest = updated
+		}
+
+		title := fmt.Sprintf("Week %d", weeklyStats.Week)
+		if weeklyStats.Date != "" {
+			title += " - " + weeklyStats.Date
+		}
+		// Several divisions can share a week number, and entry IDs must be unique
+		weekEntries[weeklyStats.Week]++
+		entryID := fmt.Sprintf("%s#week-%d", id, weeklyStats.Week)
+		if n := weekEntries[weeklyStats.Week]; n > 1 {
+			entryID += fmt.Sprintf("-%d", n)
+		}
+
+		entry := atomEntry{
+			Title:   title,
+			ID:      entryID,
+			Updated: updated.UTC().Format(time.RFC3339),
+			Summary: weekSummary(weeklyStats),
+		}
+		if cfg.Link != "" {
+			entry.Link = &atomLink{Href: cfg.Link}
+		}
+		feed.Entries = append(feed.Entries, entry)
+	}
+	if latest.IsZero() {
+		latest = fallback
+	}
+	feed.Updated = latest.UTC().Format(time.RFC3339)
+
+	data, err := xml.MarshalIndent(feed, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to encode feed: %w", err)
+	}
+	output := xml.Header + string(data) + "\n"
+
+	// Make sure the feed reads back as XML
+	var check atomFeed
+	if err := xml.Unmarshal([]byte(output), &check); err != nil {
+		return "", fmt.Errorf("generated feed is not well-formed: %w", err)
+	}
+	return output, nil
+}
+
+// SaveWeeklyFeed writes the feed from GenerateWeeklyFeed to a file
+func SaveWeeklyFeed(weeks []*models.WeeklyStats, cfg FeedConfig, filename string) error {
+	feed, err := GenerateWeeklyFeed(weeks, cfg)
+	if err != nil {
+		return err
+	}
+	if err := vfs.WriteFile(OutputFS, filename, []byte(feed)); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+	return nil
+}
+
+// weekSummary describes a week's top PPD player and best team record
+func weekSummary(weeklyStats *models.WeeklyStats) string {
+	var parts []string
+
+	if top := stats.TopPlayersByPPD(weeklyStats.PlayerStats, 1); len(top) > 0 {
+		parts = append(parts, fmt.Sprintf("Top PPD: %s (%s) %.2f", top[0].PlayerName, top[0].Team, top[0].PPD))
+	}
+
+	teams := weeklyStats.TeamStats
+	if len(teams) == 0 {
+		teams = parser.ComputeTeamStatsFromPlayers(weeklyStats.PlayerStats)
+	}
+	var best *models.TeamStat
+	for i := range teams {
+		if teams[i].GamesPlayed == 0 {
+			continue
+		}
+		if best == nil || teams[i].WinPercentage() > best.WinPercentage() ||
+			(teams[i].WinPercentage() == best.WinPercentage() && teams[i].GamesWon > best.GamesWon) {
+			best = &teams[i]
+		}
+	}
+	if best != nil {
+		parts = append(parts, fmt.Sprintf("Best record: %s %d-%d", best.TeamName, best.GamesWon, best.GamesPlayed-best.GamesWon))
+	}
+
+	if len(parts) == 0 {
+		return "No games played"
+	}
+	return strings.Join(parts, ". ") + "."
+}
@@ -0,0 +1,57 @@
+package utils
+
+import (
+	"encoding/xml"
+	"testing"
+	"time"
+
+	"github.com/myusername/dart-statistic-scraper/pkg/models"
+)
+
+func TestGenerateWeeklyFeed(t *testing.T) {
+	weeks := []*models.WeeklyStats{
+		{Week: 1, Date: "October 5, 2024", PlayerStats: []models.PlayerStat{
+			{PlayerName: "JOHN SMITH", Team: "SPEARS & BEERS", GamesPlayed: 10, GamesWon: 6, PPD: 24.5},
+			{PlayerName: "MARY <MJ> JONES", Team: "REDHEADS", GamesPlayed: 10, GamesWon: 3, PPD: 18.2},
+		}},
+		{Week: 2, Date: "Week 2, 2024"},
+	}
+	fallback := time.Date(2024, time.October, 20, 12, 0, 0, 0, time.UTC)
+
+	output, err := GenerateWeeklyFeed(weeks, FeedConfig{Title: "Dart League", Link: "https://example.com/league", Updated: fallback})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var feed atomFeed
+	if err := xml.Unmarshal([]byte(output), &feed); err != nil {
+		t.Fatalf("feed is not well-formed: %v\n%s", err, output)
+	}
+	if feed.Title != "Dart League" || feed.ID != "https://example.com/league" || feed.Updated != "2024-10-20T12:00:00Z" {
+		t.Errorf("feed = %q, %q, updated %q", feed.Title, feed.ID, feed.Updated)
+	}
+	if len(feed.Entries) != 2 {
+		t.Fatalf("feed has %d entries, want 2", len(feed.Entries))
+	}
+
+	// Newest week first; a week without a real date uses the fallback
+	want := []atomEntry{
+		{Title: "Week 2 - Week 2, 2024", ID: "https://example.com/league#week-2", Updated: "2024-10-20T12:00:00Z",
+			Link: &atomLink{Href: "https://example.com/league"}, Summary: "No games played"},
+		{Title: "Week 1 - October 5, 2024", ID: "https://example.com/league#week-1", Updated: "2024-10-05T00:00:00Z",
+			Link:    &atomLink{Href: "https://example.com/league"},
+			Summary: "Top PPD: JOHN SMITH (SPEARS & BEERS) 24.50. Best record: SPEARS & BEERS 6-4."},
+	}
+	for i, entry := range feed.Entries {
+		if entry.Title != want[i].Title || entry.ID != want[i].ID || entry.Updated != want[i].Updated ||
+			entry.Summary != want[i].Summary || entry.Link == nil || entry.Link.Href != want[i].Link.Href {
+			t.Errorf("entry %d = %+v, want %+v", i, entry, want[i])
+		}
+	}
+}
+
+func TestGenerateWeeklyFeedNeedsID(t *testing.T) {
+	if _, err := GenerateWeeklyFeed(nil, FeedConfig{Title: "Dart League"}); err == nil {
+		t.Error("GenerateWeeklyFeed() without an ID or link succeeded")
+	}
+}
@@ -0,0 +1,109 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"html/template"
+
+	"github.com/myusername/dart-statistic-scraper/pkg/models"
+)
+
+// sortablePageTemplate renders a self-contained standings page. The stats are
+// embedded as JSON using the models' tags and a small script renders the table
+// and sorts it when a column header is clicked.
+var sortablePageTemplate = template.Must(template.New("standings").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+body { font-family: sans-serif; margin: 2em; }
+table { border-collapse: collapse; }
+th, td { padding: 4px 8px; border-bottom: 1px solid #ddd; text-align: left; }
+th { cursor: pointer; background: #f4f4f4; user-select: none; }
+td.num { text-align: right; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+<table id="standings"><thead><tr></tr></thead><tbody></tbody></table>
+<script id="stats" type="application/json">{{.Data}}</script>
+<script>
+(function () {
+  var columns = [
+    ["playerName", "Player"], ["team", "Team"], ["opponent", "Opponent"], ["sancPd", "SancPd"],
+    ["gamesPlayed", "Games"], ["gamesWon", "Wins"], ["ppd", "PPD"], ["mpr", "MPR"],
+    ["hatTricks", "Hat"], ["highScore", "HstTon"], ["highCheckout", "HstOut"]
+  ];
+  var data = JSON.parse(document.getElementById("stats").textContent);
+  var rows = data.playerStats || [];
+  var table = document.getElementById("standings");
+  var sortKey = "ppd", ascending = false;
+
+  function render() {
+    rows.sort(function (a, b) {
+      var x = a[sortKey], y = b[sortKey];
+      if (x === y) return 0;
+      var order = (typeof x === "number") ? x - y : String(x).localeCompare(String(y));
+      return ascending ? order : -order;
+    });
+    var body = table.tBodies[0];
+    body.innerHTML = "";
+    rows.forEach(function (row) {
+      var tr = body.insertRow();
+      columns.forEach(function (column) {
+        var td = tr.insertCell();
+        var value = row[column[0]];
+        if (typeof value === "number") {
+          td.className = "num";
+          value = (column[0] === "ppd" || column[0] === "mpr") ? value.toFixed(2) : value;
+        }
+        td.textContent = value === undefined ? "" : value;
+      });
+    });
+  }
+
+  var header = table.tHead.rows[0];
+  columns.forEach(function (column) {
+    var th = document.createElement("th");
+    th.textContent = column[1];
+    th.addEventListener("click", function () {
+      ascending = (sortKey === column[0]) ? !ascending : true;
+      sortKey = column[0];
+      render();
+    });
+    header.appendChild(th);
+  });
+  render();
+})();
+</script>
+</body>
+</html>
+`))
+
+// SaveWeeklyStatsToSortableHTML saves a week's stats as a self-contained HTML page
+// whose columns can be sorted by clicking their headers. It needs no external files.
+func SaveWeeklyStatsToSortableHTML(weeklyStats *models.WeeklyStats, filename string) error {
+	data, err := json.Marshal(weeklyStats)
+	if err != nil {
+		return fmt.Errorf("failed to encode stats: %w", err)
+	}
+
+	f, err := OutputFS.Create(filename)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer f.Close()
+
+	page := struct {
+		Title string
+		Data  template.JS
+	}{
+		Title: fmt.Sprintf("Player Statistics for Week %d", weeklyStats.Week),
+		Data:  template.JS(data),
+	}
+	if err := sortablePageTemplate.Execute(f, page); err != nil {
+		return fmt.Errorf("failed to write HTML: %w", err)
+	}
+	return nil
+}
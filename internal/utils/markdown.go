@@ -0,0 +1,102 @@
+package utils
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/myusername/dart-statistic-scraper/pkg/models"
+	"github.com/myusername/dart-statistic-scraper/pkg/parser"
+	"github.com/myusername/dart-statistic-scraper/pkg/vfs"
+)
+
+// SaveWeeklyStatsToMarkdown saves the player statistics for a given week as
+// GitHub-flavored Markdown, with a heading and table per team followed by the
+// team's totals when the page had them
+func SaveWeeklyStatsToMarkdown(weeklyStats *models.WeeklyStats, filename string) error {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# Player Statistics for Week %d\n", weeklyStats.Week)
+	if weeklyStats.Date != "" {
+		fmt.Fprintf(&b, "\n%s\n", weeklyStats.Date)
+	}
+
+	// Drop the high score/checkout columns when the division doesn't track them
+	showHighScore := hasHighScores(weeklyStats.PlayerStats)
+	showHighCheckout := hasHighCheckouts(weeklyStats.PlayerStats)
+
+	header := "| Player | SancPd | Opponent | Games | Wins | PPD | MPR | Hat |"
+	divider := "| --- | --- | --- | ---: | ---: | ---: | ---: | ---: |"
+	if showHighScore {
+		header += " HstTon |"
+		divider += " ---: |"
+	}
+	if showHighCheckout {
+		header += " HstOut |"
+		divider += " ---: |"
+	}
+
+	// Group players by team
+	teamPlayers := make(map[string][]models.PlayerStat)
+	var teamNames []string
+	for _, player := range weeklyStats.PlayerStats {
+		if _, found := teamPlayers[player.Team]; !found {
+			teamNames = append(teamNames, player.Team)
+		}
+		teamPlayers[player.Team] = append(teamPlayers[player.Team], player)
+	}
+	sort.Strings(teamNames)
+
+	// Write each team's players, sorted by PPD
+	for _, team := range teamNames {
+		players := append([]models.PlayerStat(nil), teamPlayers[team]...)
+		sort.SliceStable(players, func(i, j int) bool {
+			return players[i].PPD > players[j].PPD
+		})
+
+		title := team
+		if title == "" {
+			title = "Unknown Team"
+		}
+		fmt.Fprintf(&b, "\n## %s\n\n%s\n%s\n", markdownEscape(title), header, divider)
+
+		for _, player := range players {
+			fmt.Fprintf(&b, "| %s | %s | %s | %d | %d | %.2f | %.2f | %d |",
+				markdownEscape(player.PlayerName), markdownEscape(player.SancPd), markdownEscape(player.Opponent),
+				player.GamesPlayed, player.GamesWon, player.PPD, player.MPR, player.HatTricks)
+			if showHighScore {
+				fmt.Fprintf(&b, " %d |", player.HighScore)
+			}
+			if showHighCheckout {
+				fmt.Fprintf(&b, " %d |", player.HighCheckout)
+			}
+			b.WriteString("\n")
+		}
+
+		if totals, found := teamTotals(weeklyStats.TeamStats, team); found {
+			fmt.Fprintf(&b, "\n**Team totals:** %d games, %d wins, PPD %.2f, MPR %.2f\n",
+				totals.GamesPlayed, totals.GamesWon, totals.PPD, totals.MPR)
+		}
+	}
+
+	if err := vfs.WriteFile(OutputFS, filename, []byte(b.String())); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+	return nil
+}
+
+// teamTotals finds a team's totals row, matching normalized team names
+func teamTotals(teamStats []models.TeamStat, team string) (models.TeamStat, bool) {
+	normTeam := parser.NormalizeTeamName(team)
+	for _, teamStat := range teamStats {
+		if parser.NormalizeTeamName(teamStat.TeamName) == normTeam {
+			return teamStat, true
+		}
+	}
+	return models.TeamStat{}, false
+}
+
+// markdownEscape escapes characters that would break a Markdown table cell
+func markdownEscape(s string) string {
+	return strings.NewReplacer("|", "\\|", "\n", " ").Replace(s)
+}
@@ -0,0 +1,134 @@
+package utils
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/myusername/dart-statistic-scraper/pkg/models"
+)
+
+// ExportColumn maps an output column header to a player field. Field is one
+// of: week, date, player, team, opponent, sancPd, gamesPlayed, gamesWon, ppd,
+// mpr, hatTricks, highScore, highCheckout, dartsThrown, plusMinus.
+type ExportColumn struct {
+	Header string `json:"header"`
+	Field  string `json:"field"`
+}
+
+// ExportProfile describes the columns, in order, written for an external tool
+type ExportProfile struct {
+	Name    string         `json:"name"`
+	Columns []ExportColumn `json:"columns"`
+}
+
+// DefaultExportProfile matches the columns of SaveWeeklyStatsToCSV
+var DefaultExportProfile = ExportProfile{
+	Name: "default",
+	Columns: []ExportColumn{
+		{Header: "Week", Field: "week"},
+		{Header: "Player", Field: "player"},
+		{Header: "Team", Field: "team"},
+		{Header: "Opponent", Field: "opponent"},
+		{Header: "SancPd", Field: "sancPd"},
+		{Header: "GamesPlayed", Field: "gamesPlayed"},
+		{Header: "GamesWon", Field: "gamesWon"},
+		{Header: "PPD", Field: "ppd"},
+		{Header: "MPR", Field: "mpr"},
+		{Header: "HatTricks", Field: "hatTricks"},
+		{Header: "HighScore", Field: "highScore"},
+		{Header: "HighCheckout", Field: "highCheckout"},
+	},
+}
+
+// ExportProfiles holds the profiles available to SaveWeeklyStatsWithProfile by name
+var ExportProfiles = map[string]ExportProfile{
+	DefaultExportProfile.Name: DefaultExportProfile,
+}
+
+// exportFields returns the value of each supported field for a player
+var exportFields = map[string]func(ws *models.WeeklyStats, player models.PlayerStat) string{
+	"week":         func(ws *models.WeeklyStats, p models.PlayerStat) string { return strconv.Itoa(ws.Week) },
+	"date":         func(ws *models.WeeklyStats, p models.PlayerStat) string { return ws.Date },
+	"player":       func(ws *models.WeeklyStats, p models.PlayerStat) string { return p.PlayerName },
+	"team":         func(ws *models.WeeklyStats, p models.PlayerStat) string { return p.Team },
+	"opponent":     func(ws *models.WeeklyStats, p models.PlayerStat) string { return p.Opponent },
+	"sancPd":       func(ws *models.WeeklyStats, p models.PlayerStat) string { return p.SancPd },
+	"gamesPlayed":  func(ws *models.WeeklyStats, p models.PlayerStat) string { return strconv.Itoa(p.GamesPlayed) },
+	"gamesWon":     func(ws *models.WeeklyStats, p models.PlayerStat) string { return strconv.Itoa(p.GamesWon) },
+	"ppd":          func(ws *models.WeeklyStats, p models.PlayerStat) string { return fmt.Sprintf("%.2f", p.PPD) },
+	"mpr":          func(ws *models.WeeklyStats, p models.PlayerStat) string { return fmt.Sprintf("%.2f", p.MPR) },
+	"hatTricks":    func(ws *models.WeeklyStats, p models.PlayerStat) string { return strconv.Itoa(p.HatTricks) },
+	"highScore":    func(ws *models.WeeklyStats, p models.PlayerStat) string { return strconv.Itoa(p.HighScore) },
+	"highCheckout": func(ws *models.WeeklyStats, p models.PlayerStat) string { return strconv.Itoa(p.HighCheckout) },
+	"dartsThrown":  func(ws *models.WeeklyStats, p models.PlayerStat) string { return strconv.Itoa(p.DartsThrown) },
+	"plusMinus":    func(ws *models.WeeklyStats, p models.PlayerStat) string { return strconv.Itoa(p.PlusMinus) },
+}
+
+// LoadExportProfiles reads a JSON array of export profiles from filename and
+// adds them to ExportProfiles, replacing any profile with the same name
+func LoadExportProfiles(filename string) error {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return fmt.Errorf("failed to read export profiles: %w", err)
+	}
+
+	var profiles []ExportProfile
+	if err := json.Unmarshal(data, &profiles); err != nil {
+		return fmt.Errorf("failed to decode export profiles: %w", err)
+	}
+
+	for _, profile := range profiles {
+		if profile.Name == "" {
+			return fmt.Errorf("export profile without a name in %s", filename)
+		}
+		for _, column := range profile.Columns {
+			if _, found := exportFields[column.Field]; !found {
+				return fmt.Errorf("export profile %s: unknown field %q", profile.Name, column.Field)
+			}
+		}
+		ExportProfiles[profile.Name] = profile
+	}
+	return nil
+}
+
+// SaveWeeklyStatsWithProfile saves the player statistics for a week to a CSV
+// file using the columns of the named export profile
+func SaveWeeklyStatsWithProfile(weeklyStats *models.WeeklyStats, profileName string, filename string) error {
+	profile, found := ExportProfiles[profileName]
+	if !found {
+		return fmt.Errorf("unknown export profile %q", profileName)
+	}
+
+	f, err := OutputFS.Create(filename)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer f.Close()
+
+	w := csv.NewWriter(f)
+	header := make([]string, len(profile.Columns))
+	for i, column := range profile.Columns {
+		header[i] = column.Header
+	}
+	if err := w.Write(header); err != nil {
+		return fmt.Errorf("failed to write header: %w", err)
+	}
+
+	for _, player := range weeklyStats.PlayerStats {
+		row := make([]string, len(profile.Columns))
+		for i, column := range profile.Columns {
+			if value, found := exportFields[column.Field]; found {
+				row[i] = value(weeklyStats, player)
+			}
+		}
+		if err := w.Write(row); err != nil {
+			return fmt.Errorf("failed to write player data: %w", err)
+		}
+	}
+
+	w.Flush()
+	return w.Error()
+}
@@ -0,0 +1,189 @@
+package utils
+
+import (
+	"encoding/csv"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/myusername/dart-statistic-scraper/pkg/models"
+	"github.com/myusername/dart-statistic-scraper/pkg/stats"
+)
+
+// DisplayTeamMVPs prints each team's most valuable player, keyed by normalized team name
+func DisplayTeamMVPs(metric string, mvps map[string]models.PlayerSeasonStat) {
+	fmt.Printf("\n=========== TEAM MVPS BY %s ===========\n", strings.ToUpper(metric))
+
+	var teams []string
+	for team := range mvps {
+		teams = append(teams, team)
+	}
+	sort.Strings(teams)
+
+	for _, team := range teams {
+		mvp := mvps[team]
+		fmt.Printf("%-20s %-26s %3d games, PPD %6.2f, MPR %5.2f\n",
+			mvp.Team, mvp.PlayerName, mvp.GamesPlayed, mvp.PPD, mvp.MPR)
+	}
+
+	fmt.Println(strings.Repeat("=", 78))
+}
+
+// DisplaySeasonAwards prints the end-of-season awards summary
+func DisplaySeasonAwards(awards stats.Awards) {
+	fmt.Println("\n=========== SEASON AWARDS ===========")
+
+	displayAward := func(title string, award *stats.Award, format string) {
+		if award == nil {
+			fmt.Printf("%-16s no qualifying player\n", title)
+			return
+		}
+		fmt.Printf("%-16s %-26s (%s): "+format+"\n", title, award.Player.PlayerName, award.Player.Team, award.Value)
+	}
+	displayAward("Highest PPD", awards.HighestPPD, "%.2f")
+	displayAward("Highest MPR", awards.HighestMPR, "%.2f")
+	displayAward("Most Hat Tricks", awards.MostHatTricks, "%.0f")
+	displayAward("Best Checkout", awards.BestCheckout, "%.0f")
+	displayAward("Most Improved", awards.MostImproved, "+%.2f PPD")
+
+	fmt.Println(strings.Repeat("=", 78))
+	DisplayTeamMVPs(stats.MetricPPD, awards.TeamMVPs)
+}
+
+// DisplayLeaderboard prints a ranked list of players across all teams
+func DisplayLeaderboard(title string, players []models.PlayerStat) {
+	fmt.Printf("\n=========== %s ===========\n", strings.ToUpper(title))
+	if len(players) == 0 {
+		fmt.Println("No qualifying players")
+	}
+
+	for i, player := range players {
+		fmt.Printf("%3d. %-26s %-20s %3d games, %3d wins, PPD %6.2f, MPR %5.2f\n",
+			i+1, player.PlayerName, player.Team, player.GamesPlayed, player.GamesWon, player.PPD, player.MPR)
+	}
+
+	fmt.Println(strings.Repeat("=", 78))
+}
+
+// DisplayTopScores prints a ranked list of players' high scores
+func DisplayTopScores(title string, players []models.PlayerStat) {
+	displayHighMarks(title, "high score", players, func(player models.PlayerStat) int { return player.HighScore })
+}
+
+// DisplayTopCheckouts prints a ranked list of players' high checkouts
+func DisplayTopCheckouts(title string, players []models.PlayerStat) {
+	displayHighMarks(title, "high checkout", players, func(player models.PlayerStat) int { return player.HighCheckout })
+}
+
+// displayHighMarks prints a ranked list of one per-player value
+func displayHighMarks(title, label string, players []models.PlayerStat, value func(models.PlayerStat) int) {
+	fmt.Printf("\n=========== %s ===========\n", strings.ToUpper(title))
+	if len(players) == 0 {
+		fmt.Printf("No %ss recorded\n", label)
+	}
+
+	for i, player := range players {
+		fmt.Printf("%3d. %-26s %-20s %s %d\n", i+1, player.PlayerName, player.Team, label, value(player))
+	}
+
+	fmt.Println(strings.Repeat("=", 78))
+}
+
+// DisplayMostImproved prints the players whose PPD rose the most since the previous week
+func DisplayMostImproved(week int, entries []stats.ImprovementEntry) {
+	fmt.Printf("\n=========== MOST IMPROVED PLAYERS FOR WEEK %d ===========\n", week)
+	if len(entries) == 0 {
+		fmt.Println("No improving players")
+	}
+
+	for i, entry := range entries {
+		fmt.Printf("%3d. %-26s %-20s %6.2f -> %6.2f (+%.2f)\n",
+			i+1, entry.Player, entry.Team, entry.PriorPPD, entry.CurrentPPD, entry.Delta)
+	}
+
+	fmt.Println(strings.Repeat("=", 78))
+}
+
+// DisplayNotableFeats prints the notable single-game feats of a week
+func DisplayNotableFeats(week int, feats []stats.Feat) {
+	fmt.Printf("\n=========== NOTABLE FEATS FOR WEEK %d ===========\n", week)
+	if len(feats) == 0 {
+		fmt.Println("No notable feats")
+	}
+
+	for _, feat := range feats {
+		fmt.Printf("%-16s %-26s (%s): %d\n", feat.Type, feat.Player, feat.Team, feat.Value)
+	}
+
+	fmt.Println(strings.Repeat("=", 78))
+}
+
+// SaveSeasonTotalsToCSV saves players' season totals to a CSV file
+func SaveSeasonTotalsToCSV(players []models.PlayerSeasonStat, filename string) error {
+	f, err := OutputFS.Create(filename)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer f.Close()
+
+	w := csv.NewWriter(f)
+	if err := w.Write([]string{"Player", "Team", "SancPd", "Weeks", "GamesPlayed", "GamesWon",
+		"PPD", "MPR", "HatTricks", "HighScore", "HighCheckout"}); err != nil {
+		return fmt.Errorf("failed to write header: %w", err)
+	}
+
+	for _, player := range players {
+		row := []string{
+			player.PlayerName,
+			player.Team,
+			player.SancPd,
+			strconv.Itoa(player.Weeks),
+			strconv.Itoa(player.GamesPlayed),
+			strconv.Itoa(player.GamesWon),
+			fmt.Sprintf("%.2f", player.PPD),
+			fmt.Sprintf("%.2f", player.MPR),
+			strconv.Itoa(player.HatTricks),
+			strconv.Itoa(player.HighScore),
+			strconv.Itoa(player.HighCheckout),
+		}
+		if err := w.Write(row); err != nil {
+			return fmt.Errorf("failed to write player data: %w", err)
+		}
+	}
+
+	w.Flush()
+	return w.Error()
+}
+
+// SaveLeagueTotalsToCSV saves league-wide weekly totals to a CSV file
+func SaveLeagueTotalsToCSV(summaries []stats.LeagueWeekSummary, filename string) error {
+	f, err := OutputFS.Create(filename)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer f.Close()
+
+	w := csv.NewWriter(f)
+	if err := w.Write([]string{"Week", "Date", "Teams", "ActivePlayers", "TotalGames", "AvgPPD", "AvgMPR"}); err != nil {
+		return fmt.Errorf("failed to write header: %w", err)
+	}
+
+	for _, summary := range summaries {
+		row := []string{
+			strconv.Itoa(summary.Week),
+			summary.Date,
+			strconv.Itoa(summary.Teams),
+			strconv.Itoa(summary.ActivePlayers),
+			strconv.Itoa(summary.TotalGames),
+			fmt.Sprintf("%.2f", summary.AvgPPD),
+			fmt.Sprintf("%.2f", summary.AvgMPR),
+		}
+		if err := w.Write(row); err != nil {
+			return fmt.Errorf("failed to write week data: %w", err)
+		}
+	}
+
+	w.Flush()
+	return w.Error()
+}
@@ -15,6 +15,8 @@ import (
 
 	"github.com/PuerkitoBio/goquery"
 	"github.com/ledongthuc/pdf"
+
+	"github.com/myusername/dart-statistic-scraper/pkg/parser"
 )
 
 // URL constants
@@ -473,82 +475,10 @@ func partialNameMatch(name1, name2 string) bool {
 	return false
 }
 
-// NormalizeTeamName standardizes team names for comparison
+// NormalizeTeamName standardizes team names for comparison using the team
+// aliases configured in the parser
 func NormalizeTeamName(name string) string {
-	// First, preserve original name for specific case handling
-	originalName := strings.ToUpper(name)
-
-	// Log the original name for debugging
-	log.Printf("Normalizing team name: '%s'", originalName)
-
-	// Handle empty or very short names
-	if len(originalName) < 3 {
-		return originalName
-	}
-
-	// Special handling for Bridge Inn teams - must be checked first
-	if strings.Contains(originalName, "BRIDGE INN 1") ||
-		(strings.Contains(originalName, "BRIDGE INN") && strings.Contains(originalName, "1") && !strings.Contains(originalName, "2")) {
-		return "BRIDGE INN 1" // Return with spaces preserved
-	} else if strings.Contains(originalName, "BRIDGE INN 2") ||
-		(strings.Contains(originalName, "BRIDGE INN") && strings.Contains(originalName, "2")) {
-		return "BRIDGE INN 2" // Return with spaces preserved
-	} else if strings.Contains(originalName, "BRIDGE INN") {
-		return "BRIDGE INN" // If it just says Bridge Inn without a number
-	}
-
-	// Special handling for Sir James Pub teams
-	if strings.Contains(originalName, "SIR JAMES PUB") {
-		if strings.Contains(originalName, "1") && !strings.Contains(originalName, "DOS") {
-			return "SIR JAMES PUB 1"
-		} else if strings.Contains(originalName, "2") || strings.Contains(originalName, "DOS") && !strings.Contains(originalName, "3") {
-			return "SIR JAMES PUB 2"
-		} else if strings.Contains(originalName, "3") {
-			return "SIR JAMES PUB 3"
-		} else if strings.Contains(originalName, "SIR JAMES PUB") {
-			return "SIR JAMES PUB"
-		}
-	}
-
-	// Remove spaces, convert to uppercase, and remove non-alphanumeric chars
-	name = strings.ToUpper(name)
-	name = regexp.MustCompile(`[^A-Z0-9]`).ReplaceAllString(name, "")
-
-	// Replace common abbreviations/alternatives
-	replacements := map[string]string{
-		"THEHUTCH":       "THE HUTCH",
-		"HUTCH":          "THE HUTCH",
-		"HARBORHILLSTOO": "HARBOR HILLS TOO",
-		"HARBORHILLS2":   "HARBOR HILLS TOO",
-		"HARBORHILLSTWO": "HARBOR HILLS TOO",
-		"HARBORHILLS":    "HARBOR HILLS",
-		"HILLSHASEYES":   "HILLS HAS EYES",
-		"EYESOFTHEHILL":  "HILLS HAS EYES",
-		"HASEYES":        "HILLS HAS EYES",
-		"SIRJAMESPUBDOS": "SIR JAMES PUB 2",
-		"SIRJAMESPUB":    "SIR JAMES PUB",
-		"SPEARSNBEERS":   "SPEARS N BEERS",
-		"SPEARS":         "SPEARS N BEERS",
-		"SPEARSANDBEERS": "SPEARS N BEERS",
-		"CAPITALIZE":     "CAPITALIZE",
-		"CAP":            "CAPITALIZE",
-		"GRANDAVE":       "GRAND AVE",
-		"GRAND":          "GRAND AVE",
-		"REDHEADS":       "REDHEADS",
-		"RED":            "REDHEADS",
-		"REDHEAD":        "REDHEADS",
-	}
-
-	for k, v := range replacements {
-		if strings.Contains(name, k) {
-			log.Printf("Matched '%s' to '%s' from '%s'", name, v, originalName)
-			return v
-		}
-	}
-
-	// For debugging - log when we couldn't find a match
-	log.Printf("No replacement found for '%s', returning original: '%s'", name, originalName)
-	return originalName
+	return parser.NormalizeTeamName(name)
 }
 
 // NormalizeURL ensures a URL is properly formatted with correct protocol slashes
@@ -0,0 +1,446 @@
+// Package app scrapes a season of standings into structured data, so the
+// scraper can be embedded in other programs as well as run from the CLI
+package app
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"net/url"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/myusername/dart-statistic-scraper/pkg/models"
+	"github.com/myusername/dart-statistic-scraper/pkg/parser"
+	"github.com/myusername/dart-statistic-scraper/pkg/scraper"
+	"github.com/myusername/dart-statistic-scraper/pkg/stats"
+	"github.com/myusername/dart-statistic-scraper/pkg/vfs"
+)
+
+// ErrNothingScraped is returned when none of the standings index pages could be fetched
+var ErrNothingScraped = errors.New("no standings index pages could be scraped")
+
+// ProgressFunc reports scraping progress. For each standings page it is
+// called with current = pages done before the page is fetched, then again with
+// current incremented once the page has been processed, whether or not it
+// succeeded. total is the number of pages selected on the index page being
+// scraped, so the count starts over for each index page.
+type ProgressFunc func(current, total int, url string)
+
+// Config describes the season ScrapeSeason scrapes
+type Config struct {
+	// URLs are the standings index pages, one per division
+	URLs []string
+	// ScheduleURL is the schedule PDF used until an index page links its own
+	ScheduleURL string
+	// SchedulePath is where the schedule PDF is saved (default: named after
+	// ScheduleURL in the pdf directory)
+	SchedulePath string
+	// CorrectedSchedules override the parsed schedule where they conflict
+	CorrectedSchedules []models.MatchSchedule
+	// SeasonPrefix selects the season's standings links (default: scraper.SeasonPrefix)
+	SeasonPrefix string
+	// CacheDir keeps a copy of every fetched page (default: scraper.CacheDir)
+	CacheDir string
+	// OutputDir holds the html and pdf directories pages and schedules are
+	// saved to (default: the current directory). Set scraper.OutputFS to a
+	// vfs.OverlayFS to keep them in memory instead.
+	OutputDir string
+	// CrawlDepth, when positive, follows standings links found on standings
+	// pages this many levels deep, for seasons split across linked pages
+	CrawlDepth int
+	// Weeks limits scraping to these weeks (default: all)
+	Weeks map[int]bool
+	// Since, when set, skips weeks scheduled before this date. Weeks without a
+	// scheduled date are kept.
+	Since time.Time
+	// CurrentWeek is checked for stale standings when set
+	CurrentWeek int
+	// LatestOnly limits scraping to the weeks of each division newer than its
+	// StoredMaxWeeks entry, or to the highest available week when nothing is
+	// stored yet
+	LatestOnly bool
+	// StoredMaxWeeks is the highest week already stored by earlier runs, keyed
+	// by the division name returned by Division
+	StoredMaxWeeks map[string]int
+	// Exclusions drops teams and players from the results
+	Exclusions stats.Exclusions
+	// Segments tag weeks whose pages don't state their own segment
+	Segments []stats.SegmentRange
+	// Progress, if set, is called before and after each standings page
+	Progress ProgressFunc
+	// OnWeek, if set, is called with each week's stats and the index page it
+	// came from as soon as the week is parsed. The stats' Division tells
+	// divisions scraped together apart.
+	OnWeek func(indexURL string, weeklyStats *models.WeeklyStats)
+}
+
+// ScrapeSeason fetches the schedule and every week's standings for the
+// configured index pages, returning the weekly stats in the order they were
+// scraped along with the schedule. Pages that fail are logged and skipped.
+func ScrapeSeason(cfg Config) ([]*models.WeeklyStats, []models.MatchSchedule, error) {
+	if len(cfg.URLs) == 0 {
+		return nil, nil, errors.New("no standings URLs given")
+	}
+
+	outputDir := cfg.OutputDir
+	if outputDir == "" {
+		outputDir = "."
+	}
+	htmlDir := filepath.Join(outputDir, "html")
+	pdfDir := filepath.Join(outputDir, "pdf")
+	for _, dir := range []string{htmlDir, pdfDir} {
+		if err := scraper.OutputFS.MkdirAll(dir, 0755); err != nil {
+			return nil, nil, fmt.Errorf("failed to create directory %s: %w", dir, err)
+		}
+	}
+
+	// Use the configured season and cache for this run only, leaving the
+	// package settings as they were for the caller's next run
+	oldSeasonPrefix, oldCacheDir, oldFetchURL := scraper.SeasonPrefix, scraper.CacheDir, parser.FetchURL
+	defer func() {
+		scraper.SeasonPrefix, scraper.CacheDir, parser.FetchURL = oldSeasonPrefix, oldCacheDir, oldFetchURL
+	}()
+	if cfg.SeasonPrefix != "" {
+		scraper.SeasonPrefix = cfg.SeasonPrefix
+	}
+	if cfg.CacheDir != "" {
+		scraper.CacheDir = cfg.CacheDir
+	}
+
+	// Initialize parser with fetch function
+	parser.FetchURL = scraper.FetchURL
+
+	// Load the schedule, falling back to the manual schedule if the PDF is unusable
+	schedulePath := cfg.SchedulePath
+	if schedulePath == "" {
+		schedulePath = filepath.Join(pdfDir, scraper.ScheduleFilename(cfg.ScheduleURL))
+	}
+	schedules, err := LoadSchedulePDF(cfg.ScheduleURL, schedulePath)
+	if err != nil {
+		log.Printf("Error loading PDF schedule: %v. Using fallback manual schedule.", err)
+		schedules = parser.ParseScheduleManually()
+	} else {
+		log.Printf("Successfully extracted %d match schedules from PDF", len(schedules))
+	}
+	if len(cfg.CorrectedSchedules) > 0 {
+		schedules = parser.MergeSchedules(cfg.CorrectedSchedules, schedules)
+	}
+
+	var allWeeklyStats []*models.WeeklyStats
+	var seasonSchedules []models.MatchSchedule
+	usedDefaultSchedule := false
+	scraped := 0
+
+	for i, url := range cfg.URLs {
+		log.Printf("Processing URL %d of %d: %s", i+1, len(cfg.URLs), url)
+
+		// Keep each division's saved pages apart, since their week numbers overlap
+		division := cfg.Division(url)
+		weekHTMLDir := htmlDir
+		if division != "" {
+			weekHTMLDir = filepath.Join(htmlDir, division)
+			if err := scraper.OutputFS.MkdirAll(weekHTMLDir, 0755); err != nil {
+				return nil, nil, fmt.Errorf("failed to create directory %s: %w", weekHTMLDir, err)
+			}
+		}
+
+		// Download and extract standings links, falling back to the saved index page when offline
+		indexHTMLPath := filepath.Join(htmlDir, fmt.Sprintf("index_%d.html", i+1))
+		htmlContent, err := scraper.FetchURL(url)
+		if errors.Is(err, scraper.ErrOfflineMiss) {
+			if fileContent, readErr := vfs.ReadFile(scraper.OutputFS, indexHTMLPath); readErr == nil {
+				log.Printf("Using saved index HTML %s", indexHTMLPath)
+				htmlContent, err = string(fileContent), nil
+			}
+		}
+		if err != nil {
+			log.Printf("Error scraping URL: %v", err)
+			continue
+		}
+		scraped++
+
+		// Save the main index page HTML
+		if err := scraper.SaveContentToFile(indexHTMLPath, htmlContent); err != nil {
+			log.Printf("Error saving index HTML: %v", err)
+		} else {
+			log.Printf("Saved index HTML to %s", indexHTMLPath)
+		}
+
+		// Prefer any schedule PDFs linked from the index page over the default
+		// schedule, for this index page only
+		urlSchedules := schedules
+		var discoveredSchedules []models.MatchSchedule
+		for _, link := range scraper.ExtractScheduleLinks(htmlContent) {
+			pdfURL := scraper.ResolveRelativeURL(url, link)
+			pdfPath := filepath.Join(pdfDir, scraper.ScheduleFilename(pdfURL))
+			linkedSchedules, err := LoadSchedulePDF(pdfURL, pdfPath)
+			if err != nil {
+				log.Printf("Error loading linked schedule %s: %v", pdfURL, err)
+				continue
+			}
+			log.Printf("Extracted %d match schedules from linked PDF %s", len(linkedSchedules), pdfURL)
+			discoveredSchedules = append(discoveredSchedules, linkedSchedules...)
+		}
+		if len(discoveredSchedules) > 0 {
+			urlSchedules = parser.MergeSchedules(cfg.CorrectedSchedules, discoveredSchedules)
+			for j := range urlSchedules {
+				if urlSchedules[j].Division == "" {
+					urlSchedules[j].Division = division
+				}
+			}
+			seasonSchedules = append(seasonSchedules, urlSchedules...)
+		} else if !usedDefaultSchedule {
+			usedDefaultSchedule = true
+			seasonSchedules = append(seasonSchedules, schedules...)
+		}
+
+		log.Println("Extracting standings links...")
+		var standingsURLs []string
+		if cfg.CrawlDepth > 0 {
+			standingsURLs = scraper.CrawlStandingsPage(url, htmlContent, cfg.CrawlDepth)
+		} else {
+			// Convert relative links to absolute URLs
+			for _, link := range scraper.ExtractStandingsLinks(htmlContent) {
+				standingsURLs = append(standingsURLs, scraper.ResolveRelativeURL(url, link))
+			}
+		}
+
+		log.Printf("Found %d standings links to process", len(standingsURLs))
+
+		// Select the requested weeks
+		type standingsPage struct {
+			url  string
+			week int
+		}
+		var pages []standingsPage
+		weekURLs := make(map[int]string)
+		for j, standingsURL := range standingsURLs {
+			// Extract the week number from the URL
+			week := j + 1 // Default: sequential weeks
+			if extractedWeek := scraper.ExtractWeekNumber(standingsURL); extractedWeek > 0 {
+				week = extractedWeek
+			}
+
+			if cfg.Weeks != nil && !cfg.Weeks[week] {
+				log.Printf("Skipping week %d (not requested)", week)
+				continue
+			}
+			if !cfg.Since.IsZero() {
+				date := scheduledDate(week, urlSchedules)
+				if date.IsZero() {
+					log.Printf("Warning: no scheduled date for week %d, including it", week)
+				} else if date.Before(cfg.Since) {
+					log.Printf("Skipping week %d (scheduled %s, before %s)", week, date.Format("2006-01-02"), cfg.Since.Format("2006-01-02"))
+					continue
+				}
+			}
+			if first, found := weekURLs[week]; found {
+				log.Printf("Skipping %s: week %d already found at %s", standingsURL, week, first)
+				continue
+			}
+			weekURLs[week] = standingsURL
+			pages = append(pages, standingsPage{standingsURL, week})
+		}
+
+		// Keep only the weeks the earlier runs haven't stored
+		if cfg.LatestOnly {
+			var available []int
+			for _, page := range pages {
+				available = append(available, page.week)
+			}
+			storedMaxWeek := cfg.StoredMaxWeeks[division]
+			latest := LatestWeeks(available, storedMaxWeek)
+			var newPages []standingsPage
+			for _, page := range pages {
+				if latest[page.week] {
+					newPages = append(newPages, page)
+				} else {
+					log.Printf("Skipping week %d (already stored)", page.week)
+				}
+			}
+			pages = newPages
+			if len(pages) == 0 {
+				log.Printf("No weeks newer than week %d on %s", storedMaxWeek, url)
+			}
+		}
+
+		// Process each standings page
+		for j, page := range pages {
+			cfg.reportProgress(j, len(pages), page.url)
+			weeklyStats, err := scrapeWeek(cfg, page.url, page.week, division, weekHTMLDir, urlSchedules)
+			cfg.reportProgress(j+1, len(pages), page.url)
+			if err != nil {
+				log.Printf("Error processing week %d: %v", page.week, err)
+				continue
+			}
+
+			allWeeklyStats = append(allWeeklyStats, weeklyStats)
+			if cfg.OnWeek != nil {
+				cfg.OnWeek(url, weeklyStats)
+			}
+		}
+	}
+
+	if scraped == 0 {
+		return nil, schedules, ErrNothingScraped
+	}
+	return allWeeklyStats, seasonSchedules, nil
+}
+
+// Division returns the name the weeks scraped from an index page are kept
+// under: empty when only one index page is scraped, so single-division runs
+// keep their files where they always were, and DivisionName otherwise
+func (cfg Config) Division(indexURL string) string {
+	if len(cfg.URLs) <= 1 {
+		return ""
+	}
+	return DivisionName(indexURL)
+}
+
+// DivisionName returns a readable division name from a standings index URL
+func DivisionName(indexURL string) string {
+	name := path.Base(indexURL)
+	if unescaped, err := url.PathUnescape(name); err == nil {
+		name = unescaped
+	}
+	return strings.TrimSuffix(name, path.Ext(name))
+}
+
+// LatestWeeks returns the weeks in available that are newer than storedMax.
+// When nothing is stored yet (storedMax <= 0) only the highest available week
+// is returned, so a first incremental run doesn't fetch the whole season.
+func LatestWeeks(available []int, storedMax int) map[int]bool {
+	latest := make(map[int]bool)
+	if storedMax <= 0 {
+		maxWeek := 0
+		for _, week := range available {
+			if week > maxWeek {
+				maxWeek = week
+			}
+		}
+		if maxWeek > 0 {
+			latest[maxWeek] = true
+		}
+		return latest
+	}
+
+	for _, week := range available {
+		if week > storedMax {
+			latest[week] = true
+		}
+	}
+	return latest
+}
+
+// reportProgress calls the Progress hook if one is set
+func (cfg Config) reportProgress(current, total int, url string) {
+	if cfg.Progress != nil {
+		cfg.Progress(current, total, url)
+	}
+}
+
+// scrapeWeek fetches, or reads the saved copy of, one week's standings page
+// and parses it into weekly stats
+func scrapeWeek(cfg Config, standingsURL string, week int, division, htmlDir string, schedules []models.MatchSchedule) (*models.WeeklyStats, error) {
+	log.Printf("Processing standings for Week %d: %s", week, standingsURL)
+
+	// Download the page, revalidating any cached copy, and fall back to the
+	// saved page when offline
+	localFilename := filepath.Join(htmlDir, fmt.Sprintf("standings_week_%d.html", week))
+	htmlContent, err := scraper.FetchURL(standingsURL)
+	if errors.Is(err, scraper.ErrOfflineMiss) {
+		if fileContent, readErr := vfs.ReadFile(scraper.OutputFS, localFilename); readErr == nil {
+			log.Printf("Using saved HTML file for week %d: %s", week, localFilename)
+			htmlContent, err = string(fileContent), nil
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error downloading standings page: %w", err)
+	}
+
+	// Save the downloaded HTML content
+	if err := scraper.SaveContentToFile(localFilename, htmlContent); err != nil {
+		log.Printf("Error saving standings HTML: %v", err)
+	} else {
+		log.Printf("Saved standings HTML for week %d to %s", week, localFilename)
+	}
+
+	return parser.ParseStandingsPage(htmlContent, week, standingsURL, cfg.PageOptions(division, schedules))
+}
+
+// PageOptions returns the options standings pages of a division are parsed
+// with, dropping excluded teams and players and tagging segments as cfg asks,
+// for use with parser.ParsePagesConcurrent and scraper.ProcessStandingsPagesConcurrent
+func (cfg Config) PageOptions(division string, schedules []models.MatchSchedule) parser.PageOptions {
+	return parser.PageOptions{
+		Config:      parser.DefaultParserConfig(),
+		Division:    division,
+		Schedules:   schedules,
+		CurrentWeek: cfg.CurrentWeek,
+		Finish: func(weeklyStats *models.WeeklyStats) *models.WeeklyStats {
+			weeklyStats = cfg.Exclusions.FilterWeeklyStats(weeklyStats)
+			stats.TagSegments([]*models.WeeklyStats{weeklyStats}, cfg.Segments)
+			return weeklyStats
+		},
+	}
+}
+
+// ScheduleDate returns the scheduled date for a week, or an empty string if unknown
+func ScheduleDate(week int, schedules []models.MatchSchedule) string {
+	for _, schedule := range schedules {
+		if schedule.Week == week {
+			return schedule.Date
+		}
+	}
+	return ""
+}
+
+// scheduledDate returns the parsed scheduled date for a week, or the zero time
+// if the schedule has no parseable date for it
+func scheduledDate(week int, schedules []models.MatchSchedule) time.Time {
+	for _, schedule := range schedules {
+		if schedule.Week != week {
+			continue
+		}
+		if !schedule.ParsedDate.IsZero() {
+			return schedule.ParsedDate
+		}
+		if date, err := parser.ParseMatchDate(schedule.Date); err == nil {
+			return date
+		}
+	}
+	return time.Time{}
+}
+
+// LoadSchedulePDF downloads a schedule PDF unless it is already saved at
+// localPath, then extracts the match schedules from its text
+func LoadSchedulePDF(pdfURL, localPath string) ([]models.MatchSchedule, error) {
+	data, err := vfs.ReadFile(scraper.OutputFS, localPath)
+	if errors.Is(err, fs.ErrNotExist) {
+		log.Printf("Attempting to download schedule PDF from %s", pdfURL)
+		if err := scraper.DownloadPDF(pdfURL, localPath); err != nil {
+			return nil, fmt.Errorf("error downloading PDF schedule: %w", err)
+		}
+		data, err = vfs.ReadFile(scraper.OutputFS, localPath)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading PDF: %w", err)
+	}
+
+	pdfText, err := parser.ReadPDFFromReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("error reading PDF text: %w", err)
+	}
+
+	schedules := parser.ExtractScheduleFromText(pdfText)
+	if len(schedules) == 0 {
+		return nil, fmt.Errorf("no schedules extracted from %s", localPath)
+	}
+
+	return schedules, nil
+}
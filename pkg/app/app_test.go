@@ -0,0 +1,238 @@
+package app
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/myusername/dart-statistic-scraper/pkg/models"
+	"github.com/myusername/dart-statistic-scraper/pkg/parser"
+	"github.com/myusername/dart-statistic-scraper/pkg/scraper"
+	"github.com/myusername/dart-statistic-scraper/pkg/stats"
+	"github.com/myusername/dart-statistic-scraper/pkg/storage"
+	"github.com/myusername/dart-statistic-scraper/pkg/vfs"
+)
+
+// standingsPage returns a week's standings page with one team and one player
+func standingsPage(team, player string) string {
+	return fmt.Sprintf(`<html><body>
+<p>Combined X01/Cricket games, sorted by Team + PPD:</p>
+<table>
+<tr><th>Player</th><th>SancPd</th><th>Games</th><th>Wins</th><th>PPD</th><th>MPR</th><th>Hat</th><th>High</th><th>Out</th></tr>
+<tr><td colspan="9">%s</td></tr>
+<tr><td>%s</td><td>A</td><td>10</td><td>6</td><td>24.50</td><td>2.10</td><td>1</td><td>140</td><td>96</td></tr>
+</table>
+<p>Most Improved Players for week</p>
+</body></html>`, team, player)
+}
+
+// useMemoryOutput keeps the files a test scrape writes in memory
+func useMemoryOutput(t *testing.T) *vfs.MemFS {
+	t.Helper()
+	fsys := vfs.NewMemFS()
+	oldFS, oldCacheDir := scraper.OutputFS, scraper.CacheDir
+	scraper.OutputFS = fsys
+	scraper.CacheDir = ""
+	scraper.SetRequestInterval(0)
+	t.Cleanup(func() {
+		scraper.OutputFS = oldFS
+		scraper.CacheDir = oldCacheDir
+		scraper.SetRequestInterval(500 * time.Millisecond)
+	})
+	return fsys
+}
+
+func TestScrapeSeasonKeepsDivisionsApart(t *testing.T) {
+	fsys := useMemoryOutput(t)
+	pages := map[string]string{
+		"/SUN1.html":        `<a href="TESTWk3SUN1.html">Week 3</a>`,
+		"/TESTWk3SUN1.html": standingsPage("HARBOR HILLS", "JOHN SMITH"),
+		"/SUN2.html":        `<a href="TESTWk3SUN2.html">Week 3</a>`,
+		"/TESTWk3SUN2.html": standingsPage("REDHEADS", "MARY JONES"),
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page, found := pages[r.URL.Path]
+		if !found {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprint(w, page)
+	}))
+	defer srv.Close()
+
+	players := make(map[string]string)
+	weeks, _, err := ScrapeSeason(Config{
+		URLs:         []string{srv.URL + "/SUN1.html", srv.URL + "/SUN2.html"},
+		ScheduleURL:  srv.URL + "/schedule.pdf",
+		SeasonPrefix: "TEST",
+		OutputDir:    "out",
+		OnWeek: func(indexURL string, ws *models.WeeklyStats) {
+			if DivisionName(indexURL) != ws.Division {
+				t.Errorf("week from %s has division %q", indexURL, ws.Division)
+			}
+			for _, player := range ws.PlayerStats {
+				players[ws.Division] = player.PlayerName
+			}
+		},
+	})
+	if err != nil {
+		t.Fatalf("ScrapeSeason: %v", err)
+	}
+	if len(weeks) != 2 {
+		t.Fatalf("ScrapeSeason returned %d weeks, want 2", len(weeks))
+	}
+
+	// Both divisions have a week 3, and each keeps its own players
+	want := map[string]string{"SUN1": "JOHN SMITH", "SUN2": "MARY JONES"}
+	for division, player := range want {
+		if players[division] != player {
+			t.Errorf("division %s player = %q, want %q", division, players[division], player)
+		}
+	}
+
+	// Each division's page is saved under its own name
+	var saved []string
+	for _, name := range fsys.Files() {
+		saved = append(saved, name)
+	}
+	sort.Strings(saved)
+	for _, name := range []string{"out/html/SUN1/standings_week_3.html", "out/html/SUN2/standings_week_3.html"} {
+		if i := sort.SearchStrings(saved, name); i == len(saved) || saved[i] != name {
+			t.Errorf("%s not saved; saved files: %v", name, saved)
+		}
+	}
+}
+
+func TestConfigDivision(t *testing.T) {
+	single := Config{URLs: []string{"https://example.com/FALL2024%2024SUN1OZCounty.html"}}
+	if got := single.Division(single.URLs[0]); got != "" {
+		t.Errorf("Division with one URL = %q, want empty", got)
+	}
+
+	several := Config{URLs: []string{single.URLs[0], "https://example.com/FALL2024%2024SUN2.html"}}
+	if got := several.Division(several.URLs[0]); got != "FALL2024 24SUN1OZCounty" {
+		t.Errorf("Division with several URLs = %q, want %q", got, "FALL2024 24SUN1OZCounty")
+	}
+}
+
+func TestScrapeWeekFindsOpponentInDivision(t *testing.T) {
+	fsys := useMemoryOutput(t)
+
+	// Parse the saved pages rather than fetching them
+	scraper.Offline = true
+	defer func() { scraper.Offline = false }()
+
+	// Both divisions have a HARBOR HILLS, each playing a different team
+	schedules := []models.MatchSchedule{
+		{Week: 3, HomeTeam: "HARBOR HILLS", AwayTeam: "REDHEADS", Division: "SUN1"},
+		{Week: 3, HomeTeam: "HARBOR HILLS", AwayTeam: "BULLSEYES", Division: "SUN2"},
+	}
+	for division, want := range map[string]string{"SUN1": "REDHEADS", "SUN2": "BULLSEYES"} {
+		htmlDir := "html/" + division
+		if err := vfs.WriteFile(fsys, htmlDir+"/standings_week_3.html", []byte(standingsPage("HARBOR HILLS", "JOHN SMITH"))); err != nil {
+			t.Fatalf("WriteFile: %v", err)
+		}
+
+		ws, err := scrapeWeek(Config{}, "https://example.com/TESTWk3"+division+".html", 3, division, htmlDir, schedules)
+		if err != nil {
+			t.Fatalf("scrapeWeek(%s): %v", division, err)
+		}
+		if len(ws.PlayerStats) != 1 {
+			t.Fatalf("scrapeWeek(%s) returned %d players, want 1", division, len(ws.PlayerStats))
+		}
+		if got := ws.PlayerStats[0].Opponent; got != want {
+			t.Errorf("division %s opponent = %q, want %q", division, got, want)
+		}
+	}
+}
+
+func TestLatestWeeks(t *testing.T) {
+	tests := []struct {
+		name      string
+		stored    []int
+		available []int
+		want      []int
+	}{
+		{"empty store takes the newest week", nil, []int{1, 2, 3, 4}, []int{4}},
+		{"current store takes nothing", []int{1, 2, 3, 4}, []int{1, 2, 3, 4}, nil},
+		{"store with gaps takes every newer week", []int{1, 3}, []int{1, 2, 3, 4, 6}, []int{4, 6}},
+		{"no weeks available", []int{1, 2}, nil, nil},
+	}
+
+	for _, tt := range tests {
+		store, err := storage.NewFileStoreFS(vfs.NewMemFS(), "data")
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, week := range tt.stored {
+			if err := store.SaveWeeklyStats(&models.WeeklyStats{Week: week}); err != nil {
+				t.Fatal(err)
+			}
+		}
+		storedMax, err := storage.MaxWeek(store)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var got []int
+		for week := range LatestWeeks(tt.available, storedMax) {
+			got = append(got, week)
+		}
+		sort.Ints(got)
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: LatestWeeks() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestScrapeSeasonRestoresSettings(t *testing.T) {
+	useMemoryOutput(t)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.NotFound(w, r)
+	}))
+	defer srv.Close()
+
+	oldSeasonPrefix := scraper.SeasonPrefix
+	ScrapeSeason(Config{
+		URLs:         []string{srv.URL + "/SUN1.html"},
+		ScheduleURL:  srv.URL + "/schedule.pdf",
+		SeasonPrefix: "Spring2030",
+		CacheDir:     "cache",
+		OutputDir:    "out",
+	})
+	if scraper.SeasonPrefix != oldSeasonPrefix {
+		t.Errorf("SeasonPrefix = %q after ScrapeSeason, want %q", scraper.SeasonPrefix, oldSeasonPrefix)
+	}
+	if scraper.CacheDir != "" {
+		t.Errorf("CacheDir = %q after ScrapeSeason, want empty", scraper.CacheDir)
+	}
+	if parser.FetchURL != nil {
+		t.Error("parser.FetchURL set after ScrapeSeason")
+	}
+}
+
+func TestConfigPageOptions(t *testing.T) {
+	cfg := Config{
+		Exclusions: stats.NewExclusions(nil, []string{"JOHN SMITH"}),
+		Segments:   []stats.SegmentRange{{Name: "first", FirstWeek: 1, LastWeek: 13}},
+	}
+	page := strings.Replace(standingsPage("HARBOR HILLS", "JOHN SMITH"), "</table>",
+		`<tr><td>MARY JONES</td><td>B</td><td>10</td><td>4</td><td>20.10</td><td>1.80</td><td>0</td><td>100</td><td>40</td></tr>
+</table>`, 1)
+
+	ws, err := parser.ParseStandingsPage(page, 3, "TESTWk3.html", cfg.PageOptions("SUN1", nil))
+	if err != nil {
+		t.Fatalf("ParseStandingsPage: %v", err)
+	}
+	if len(ws.PlayerStats) != 1 || ws.PlayerStats[0].PlayerName != "MARY JONES" {
+		t.Errorf("players = %+v, want only MARY JONES", ws.PlayerStats)
+	}
+	if ws.Division != "SUN1" || ws.Segment != "first" {
+		t.Errorf("division %q, segment %q; want SUN1, first", ws.Division, ws.Segment)
+	}
+}
@@ -0,0 +1,52 @@
+//go:build sheets
+
+package sheets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"google.golang.org/api/option"
+	sheetsapi "google.golang.org/api/sheets/v4"
+
+	"github.com/myusername/dart-statistic-scraper/pkg/models"
+)
+
+// PushWeeklyStats clears the sheetName tab of the spreadsheet and writes the
+// week's player rows to it, authenticating with the service-account JSON key
+// in creds
+func PushWeeklyStats(ctx context.Context, spreadsheetID, sheetName string, ws *models.WeeklyStats, creds []byte) error {
+	if spreadsheetID == "" || sheetName == "" {
+		return errors.New("spreadsheet ID and sheet name are required")
+	}
+
+	service, err := sheetsapi.NewService(ctx,
+		option.WithCredentialsJSON(creds),
+		option.WithScopes(sheetsapi.SpreadsheetsScope))
+	if err != nil {
+		return fmt.Errorf("failed to create Sheets client: %w", err)
+	}
+
+	// Quote the tab name so names with spaces work in A1 notation
+	sheetRange := "'" + strings.ReplaceAll(sheetName, "'", "''") + "'"
+
+	// Clear old rows first so a shorter week doesn't leave stale players behind
+	if _, err := service.Spreadsheets.Values.Clear(spreadsheetID, sheetRange, &sheetsapi.ClearValuesRequest{}).Context(ctx).Do(); err != nil {
+		return fmt.Errorf("failed to clear sheet %s: %w", sheetName, err)
+	}
+
+	values := &sheetsapi.ValueRange{
+		Range:          sheetRange,
+		MajorDimension: "ROWS",
+		Values:         WeeklyStatsValues(ws),
+	}
+	if _, err := service.Spreadsheets.Values.Update(spreadsheetID, sheetRange, values).ValueInputOption("RAW").Context(ctx).Do(); err != nil {
+		return fmt.Errorf("failed to write sheet %s: %w", sheetName, err)
+	}
+
+	log.Printf("Pushed %d players to sheet %s", len(values.Values)-1, sheetName)
+	return nil
+}
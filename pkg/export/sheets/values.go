@@ -0,0 +1,39 @@
+// Package sheets pushes scraped statistics to a Google Sheets tab. The Sheets
+// API client is only built with the "sheets" build tag, so the core tool does
+// not carry it.
+package sheets
+
+import "github.com/myusername/dart-statistic-scraper/pkg/models"
+
+// Header is the first row written to the sheet tab
+var Header = []interface{}{
+	"Week", "Player", "Team", "Opponent", "SancPd",
+	"GamesPlayed", "GamesWon", "PPD", "MPR", "HatTricks",
+	"HighScore", "HighCheckout",
+}
+
+// WeeklyStatsValues converts a week's player rows to the cell values written
+// to the sheet, starting with the header row
+func WeeklyStatsValues(ws *models.WeeklyStats) [][]interface{} {
+	values := [][]interface{}{Header}
+	if ws == nil {
+		return values
+	}
+	for _, player := range ws.PlayerStats {
+		values = append(values, []interface{}{
+			ws.Week,
+			player.PlayerName,
+			player.Team,
+			player.Opponent,
+			player.SancPd,
+			player.GamesPlayed,
+			player.GamesWon,
+			player.PPD,
+			player.MPR,
+			player.HatTricks,
+			player.HighScore,
+			player.HighCheckout,
+		})
+	}
+	return values
+}
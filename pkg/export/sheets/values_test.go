@@ -0,0 +1,44 @@
+package sheets
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/myusername/dart-statistic-scraper/pkg/models"
+)
+
+func TestWeeklyStatsValues(t *testing.T) {
+	ws := &models.WeeklyStats{
+		Week: 3,
+		PlayerStats: []models.PlayerStat{
+			{PlayerName: "JOHN SMITH", Team: "HARBOR HILLS", Opponent: "REDHEADS", SancPd: "A",
+				GamesPlayed: 10, GamesWon: 7, PPD: 25.3, MPR: 2.81, HatTricks: 2, HighScore: 140, HighCheckout: 96},
+			{PlayerName: "MARY JONES", Team: "REDHEADS", Opponent: "HARBOR HILLS",
+				GamesPlayed: 8, GamesWon: 3, PPD: 18.4},
+		},
+	}
+
+	want := [][]interface{}{
+		Header,
+		{3, "JOHN SMITH", "HARBOR HILLS", "REDHEADS", "A", 10, 7, 25.3, 2.81, 2, 140, 96},
+		{3, "MARY JONES", "REDHEADS", "HARBOR HILLS", "", 8, 3, 18.4, 0.0, 0, 0, 0},
+	}
+	got := WeeklyStatsValues(ws)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("WeeklyStatsValues() =\n%v\nwant\n%v", got, want)
+	}
+	for i, row := range got {
+		if len(row) != len(Header) {
+			t.Errorf("row %d has %d cells, want %d", i, len(row), len(Header))
+		}
+	}
+}
+
+func TestWeeklyStatsValuesEmpty(t *testing.T) {
+	for _, ws := range []*models.WeeklyStats{nil, {Week: 1}} {
+		got := WeeklyStatsValues(ws)
+		if len(got) != 1 || !reflect.DeepEqual(got[0], Header) {
+			t.Errorf("WeeklyStatsValues(%v) = %v, want only the header", ws, got)
+		}
+	}
+}
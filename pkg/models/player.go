@@ -1,41 +1,115 @@
 // Package models contains data structures for dart league statistics
 package models
 
+import "time"
+
+// SchemaVersion identifies the layout of the models when serialized. It is
+// written into JSON output and stored data, and must be bumped whenever a
+// field is added, removed or renamed so readers can migrate older files.
+const SchemaVersion = 12
+
 // PlayerStat holds statistics for a player
 type PlayerStat struct {
-	PlayerName   string
-	Team         string
-	Opponent     string
-	SancPd       string
-	GamesPlayed  int
-	GamesWon     int
-	PPD          float64
-	MPR          float64
-	HatTricks    int
-	HighScore    int
-	HighCheckout int
+	PlayerName   string  `json:"playerName"`
+	Team         string  `json:"team"`
+	Opponent     string  `json:"opponent"`
+	SancPd       string  `json:"sancPd"`
+	Rating       Rating  `json:"rating,omitempty"`
+	GamesPlayed  int     `json:"gamesPlayed"`
+	GamesWon     int     `json:"gamesWon"`
+	PPD          float64 `json:"ppd"`
+	MPR          float64 `json:"mpr"`
+	HatTricks    int     `json:"hatTricks"`
+	HighScore    int     `json:"highScore"`
+	HighCheckout int     `json:"highCheckout"`
+	DartsThrown  int     `json:"dartsThrown,omitempty"`
+	PlusMinus    int     `json:"plusMinus,omitempty"`
+	SeedNumber   int     `json:"seedNumber,omitempty"`
+}
+
+// WinPercentage returns the percentage (0-100) of games the player won, or 0
+// when they played none
+func (p PlayerStat) WinPercentage() float64 {
+	return winPercentage(p.GamesWon, p.GamesPlayed)
+}
+
+// IsValid reports whether the row names a player who played at least one game
+func (p PlayerStat) IsValid() bool {
+	return p.PlayerName != "" && p.GamesPlayed > 0
 }
 
 // TeamStat holds statistics for a team
 type TeamStat struct {
-	TeamName    string
-	GamesPlayed int
-	GamesWon    int
-	PPD         float64
-	MPR         float64
+	TeamName    string  `json:"teamName"`
+	GamesPlayed int     `json:"gamesPlayed"`
+	GamesWon    int     `json:"gamesWon"`
+	PPD         float64 `json:"ppd"`
+	MPR         float64 `json:"mpr"`
+	DartsThrown int     `json:"dartsThrown,omitempty"`
+}
+
+// WinPercentage returns the percentage (0-100) of games the team won, or 0
+// when it played none
+func (t TeamStat) WinPercentage() float64 {
+	return winPercentage(t.GamesWon, t.GamesPlayed)
+}
+
+// winPercentage returns won as a percentage of played, avoiding division by zero
+func winPercentage(won, played int) float64 {
+	if played == 0 {
+		return 0
+	}
+	return float64(won) / float64(played) * 100
 }
 
-// WeeklyStats holds the stats for a specific week
+// WeeklyStats holds the stats for a specific week. Division names the
+// standings index page the week came from when several were scraped together.
 type WeeklyStats struct {
-	Week        int
-	PlayerStats []PlayerStat
-	TeamStats   []TeamStat
+	Week        int          `json:"week"`
+	Division    string       `json:"division,omitempty"`
+	Date        string       `json:"date,omitempty"`
+	Segment     string       `json:"segment,omitempty"`
+	PlayerStats []PlayerStat `json:"playerStats"`
+	TeamStats   []TeamStat   `json:"teamStats"`
 }
 
-// MatchSchedule holds scheduling information for a match
+// MatchSchedule holds scheduling information for a match. In formats where
+// opponents are specific doubles/triples pairings, the optional sub-match
+// labels name the pairing playing for each side. Scores are the games each
+// side won and are only meaningful when HasScore is set. ParsedDate is Date as
+// a time, and is zero when Date is a placeholder such as "Week 3, 2024".
 type MatchSchedule struct {
-	Week     int
-	Date     string
-	HomeTeam string
-	AwayTeam string
+	Week         int       `json:"week"`
+	Date         string    `json:"date"`
+	ParsedDate   time.Time `json:"parsedDate"`
+	HomeTeam     string    `json:"homeTeam"`
+	AwayTeam     string    `json:"awayTeam"`
+	Division     string    `json:"division,omitempty"`
+	HomeSubMatch string    `json:"homeSubMatch,omitempty"`
+	AwaySubMatch string    `json:"awaySubMatch,omitempty"`
+	HomeScore    int       `json:"homeScore,omitempty"`
+	AwayScore    int       `json:"awayScore,omitempty"`
+	HasScore     bool      `json:"hasScore,omitempty"`
+}
+
+// PlayerSeasonStat holds a player's cumulative statistics across several weeks
+type PlayerSeasonStat struct {
+	PlayerName   string  `json:"playerName"`
+	Team         string  `json:"team"`
+	SancPd       string  `json:"sancPd"`
+	Weeks        int     `json:"weeks"`
+	GamesPlayed  int     `json:"gamesPlayed"`
+	GamesWon     int     `json:"gamesWon"`
+	PPD          float64 `json:"ppd"`
+	MPR          float64 `json:"mpr"`
+	HatTricks    int     `json:"hatTricks"`
+	HighScore    int     `json:"highScore"`
+	HighCheckout int     `json:"highCheckout"`
+}
+
+// SeasonStats holds season-to-date totals for every player, keyed by
+// normalized player name and team ("NAME|TEAM")
+type SeasonStats struct {
+	Weeks   []int                       `json:"weeks"`
+	Players map[string]PlayerSeasonStat `json:"players"`
 }
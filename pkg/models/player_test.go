@@ -0,0 +1,45 @@
+package models
+
+import "testing"
+
+func TestWinPercentage(t *testing.T) {
+	tests := []struct {
+		won, played int
+		want        float64
+	}{
+		{0, 0, 0},
+		{3, 0, 0},
+		{0, 10, 0},
+		{3, 4, 75},
+		{12, 12, 100},
+	}
+
+	for _, tt := range tests {
+		player := PlayerStat{GamesWon: tt.won, GamesPlayed: tt.played}
+		if got := player.WinPercentage(); got != tt.want {
+			t.Errorf("PlayerStat{%d of %d}.WinPercentage() = %v, want %v", tt.won, tt.played, got, tt.want)
+		}
+		team := TeamStat{GamesWon: tt.won, GamesPlayed: tt.played}
+		if got := team.WinPercentage(); got != tt.want {
+			t.Errorf("TeamStat{%d of %d}.WinPercentage() = %v, want %v", tt.won, tt.played, got, tt.want)
+		}
+	}
+}
+
+func TestPlayerStatIsValid(t *testing.T) {
+	tests := []struct {
+		player PlayerStat
+		want   bool
+	}{
+		{PlayerStat{PlayerName: "JOHN SMITH", GamesPlayed: 10}, true},
+		{PlayerStat{PlayerName: "JOHN SMITH"}, false},
+		{PlayerStat{GamesPlayed: 10}, false},
+		{PlayerStat{PlayerName: "JOHN SMITH", GamesPlayed: -1}, false},
+	}
+
+	for _, tt := range tests {
+		if got := tt.player.IsValid(); got != tt.want {
+			t.Errorf("%+v.IsValid() = %v, want %v", tt.player, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package models
+
+import "strings"
+
+// Rating is a player's sanctioned rating on the league's ladder
+type Rating string
+
+// The rating ladder, strongest first
+const (
+	RatingAA Rating = "AA"
+	RatingA  Rating = "A"
+	RatingBB Rating = "BB"
+	RatingB  Rating = "B"
+	RatingCC Rating = "CC"
+	RatingC  Rating = "C"
+)
+
+// RatingLadder lists the known ratings, strongest first
+var RatingLadder = []Rating{RatingAA, RatingA, RatingBB, RatingB, RatingCC, RatingC}
+
+// ParseRating reads a rating like "aa" or " B ", reporting false for anything
+// not on RatingLadder, such as "XZ"
+func ParseRating(s string) (Rating, bool) {
+	rating := Rating(strings.ToUpper(strings.TrimSpace(s)))
+	for _, known := range RatingLadder {
+		if rating == known {
+			return rating, true
+		}
+	}
+	return "", false
+}
+
+// Rank returns the rating's position on RatingLadder, 0 for the strongest, or
+// -1 for an unknown rating
+func (r Rating) Rank() int {
+	for i, known := range RatingLadder {
+		if r == known {
+			return i
+		}
+	}
+	return -1
+}
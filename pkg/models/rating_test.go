@@ -0,0 +1,43 @@
+package models
+
+import "testing"
+
+func TestParseRating(t *testing.T) {
+	tests := []struct {
+		in     string
+		want   Rating
+		wantOK bool
+	}{
+		{"AA", RatingAA, true},
+		{"A", RatingA, true},
+		{"BB", RatingBB, true},
+		{"B", RatingB, true},
+		{"CC", RatingCC, true},
+		{"C", RatingC, true},
+		{" bb ", RatingBB, true},
+		{"XZ", "", false},
+		{"JO", "", false},
+		{"AAA", "", false},
+		{"D", "", false},
+		{"", "", false},
+		{"10", "", false},
+	}
+
+	for _, tt := range tests {
+		got, ok := ParseRating(tt.in)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("ParseRating(%q) = %q, %v; want %q, %v", tt.in, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestRatingRank(t *testing.T) {
+	for i, rating := range RatingLadder {
+		if got := rating.Rank(); got != i {
+			t.Errorf("%s.Rank() = %d, want %d", rating, got, i)
+		}
+	}
+	if got := Rating("XZ").Rank(); got != -1 {
+		t.Errorf("XZ.Rank() = %d, want -1", got)
+	}
+}
@@ -0,0 +1,81 @@
+// Package parquetexport writes scraped statistics as Parquet files for data
+// analysis tools. It lives in its own package so the Parquet dependency is only
+// linked into programs that use it.
+package parquetexport
+
+import (
+	"fmt"
+
+	"github.com/parquet-go/parquet-go"
+
+	"github.com/myusername/dart-statistic-scraper/pkg/models"
+	"github.com/myusername/dart-statistic-scraper/pkg/vfs"
+)
+
+// OutputFS is the filesystem Parquet files are written to
+var OutputFS vfs.FS = vfs.OS{}
+
+// playerWeekRow is one row of the season dataset: a player's stats for a week
+type playerWeekRow struct {
+	Week         int32   `parquet:"week"`
+	Date         string  `parquet:"date,optional"`
+	Segment      string  `parquet:"segment,optional"`
+	PlayerName   string  `parquet:"playerName"`
+	Team         string  `parquet:"team"`
+	Opponent     string  `parquet:"opponent,optional"`
+	SancPd       string  `parquet:"sancPd,optional"`
+	GamesPlayed  int32   `parquet:"gamesPlayed"`
+	GamesWon     int32   `parquet:"gamesWon"`
+	PPD          float64 `parquet:"ppd"`
+	MPR          float64 `parquet:"mpr"`
+	HatTricks    int32   `parquet:"hatTricks"`
+	HighScore    int32   `parquet:"highScore"`
+	HighCheckout int32   `parquet:"highCheckout"`
+	DartsThrown  int32   `parquet:"dartsThrown"`
+	PlusMinus    int32   `parquet:"plusMinus"`
+}
+
+// SaveSeasonToParquet writes one row per player per week to a Parquet file
+func SaveSeasonToParquet(weeks []*models.WeeklyStats, filename string) error {
+	var rows []playerWeekRow
+	for _, weeklyStats := range weeks {
+		if weeklyStats == nil {
+			continue
+		}
+		for _, player := range weeklyStats.PlayerStats {
+			rows = append(rows, playerWeekRow{
+				Week:         int32(weeklyStats.Week),
+				Date:         weeklyStats.Date,
+				Segment:      weeklyStats.Segment,
+				PlayerName:   player.PlayerName,
+				Team:         player.Team,
+				Opponent:     player.Opponent,
+				SancPd:       player.SancPd,
+				GamesPlayed:  int32(player.GamesPlayed),
+				GamesWon:     int32(player.GamesWon),
+				PPD:          player.PPD,
+				MPR:          player.MPR,
+				HatTricks:    int32(player.HatTricks),
+				HighScore:    int32(player.HighScore),
+				HighCheckout: int32(player.HighCheckout),
+				DartsThrown:  int32(player.DartsThrown),
+				PlusMinus:    int32(player.PlusMinus),
+			})
+		}
+	}
+
+	f, err := OutputFS.Create(filename)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer f.Close()
+
+	w := parquet.NewGenericWriter[playerWeekRow](f)
+	if _, err := w.Write(rows); err != nil {
+		return fmt.Errorf("failed to write rows: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("failed to finish parquet file: %w", err)
+	}
+	return nil
+}
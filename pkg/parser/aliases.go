@@ -0,0 +1,146 @@
+package parser
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/myusername/dart-statistic-scraper/pkg/models"
+)
+
+//go:embed team_aliases.json
+var defaultTeamAliases []byte
+
+// teamAliases maps team name spellings to canonical names and is used by
+// NormalizeTeamName. It starts with the bundled defaults; SetTeamAliases
+// replaces it to support another league.
+var teamAliases = mustParseTeamAliases(defaultTeamAliases)
+
+// teamAliasKeys holds the spellings in teamAliases, longest first
+var teamAliasKeys = sortedAliasKeys(teamAliases)
+
+// SetTeamAliases makes NormalizeTeamName use the given aliases, such as those
+// returned by LoadTeamAliases
+func SetTeamAliases(aliases map[string]string) {
+	teamAliases = aliases
+	teamAliasKeys = sortedAliasKeys(aliases)
+}
+
+// nonAlphanumeric matches everything dropped when comparing team names
+var nonAlphanumeric = regexp.MustCompile(`[^A-Z0-9]`)
+
+// LoadTeamAliases reads team aliases from a JSON file mapping each spelling to
+// its canonical name, e.g. {"Sir James Pub Dos": "SIR JAMES PUB 2"}. Spellings
+// match case-insensitively, ignoring spaces and punctuation, anywhere in a
+// team name; the longest matching spelling wins, so numbered teams such as
+// "SIR JAMES PUB 2" can be listed alongside the plain venue name.
+func LoadTeamAliases(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading team aliases: %w", err)
+	}
+	aliases, err := parseTeamAliases(data)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing team aliases %s: %w", path, err)
+	}
+	return aliases, nil
+}
+
+// parseTeamAliases decodes an alias file, compacting each spelling
+func parseTeamAliases(data []byte) (map[string]string, error) {
+	var raw map[string]string
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+
+	aliases := make(map[string]string, len(raw))
+	for spelling, canonical := range raw {
+		key := compactTeamName(spelling)
+		if key == "" {
+			return nil, fmt.Errorf("alias %q has no letters or digits", spelling)
+		}
+		aliases[key] = strings.ToUpper(strings.TrimSpace(canonical))
+	}
+	return aliases, nil
+}
+
+// mustParseTeamAliases parses the bundled aliases, which must be valid
+func mustParseTeamAliases(data []byte) map[string]string {
+	aliases, err := parseTeamAliases(data)
+	if err != nil {
+		panic(fmt.Sprintf("invalid bundled team aliases: %v", err))
+	}
+	return aliases
+}
+
+// compactTeamName uppercases a name and drops everything but letters and digits
+func compactTeamName(name string) string {
+	return nonAlphanumeric.ReplaceAllString(strings.ToUpper(name), "")
+}
+
+// sortedAliasKeys returns the spellings in aliases, longest first so numbered
+// teams beat the venue name
+func sortedAliasKeys(aliases map[string]string) []string {
+	keys := make([]string, 0, len(aliases))
+	for key := range aliases {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if len(keys[i]) != len(keys[j]) {
+			return len(keys[i]) > len(keys[j])
+		}
+		return keys[i] < keys[j]
+	})
+	return keys
+}
+
+// NormalizeTeamNameWith standardizes a team name using the given aliases.
+// Names matching no alias are returned uppercased. The aliases are sorted on
+// every call, so set a league's aliases with SetTeamAliases instead of
+// normalizing many names this way.
+func NormalizeTeamNameWith(name string, aliases map[string]string) string {
+	return normalizeTeamName(name, aliases, sortedAliasKeys(aliases))
+}
+
+// normalizeTeamName standardizes a team name using aliases whose keys are
+// already sorted longest first
+func normalizeTeamName(name string, aliases map[string]string, keys []string) string {
+	originalName := strings.ToUpper(name)
+
+	// Normalize the venue part of lettered teams and keep the letter
+	if TeamLetterSuffixes {
+		if base, suffix, ok := splitLetterSuffix(originalName); ok {
+			return normalizeTeamName(base, aliases, keys) + " " + suffix
+		}
+	}
+
+	compact := compactTeamName(name)
+	for _, key := range keys {
+		if strings.Contains(compact, key) {
+			return aliases[key]
+		}
+	}
+
+	return originalName
+}
+
+// PlayerTeamOverrides reassigns players the standings list under the wrong
+// team. It maps a normalized player name (uppercase, single spaces) to the team
+// they play for, and is empty by default; callers populate it for their league.
+var PlayerTeamOverrides = map[string]string{}
+
+// applyPlayerTeamOverrides moves players listed in PlayerTeamOverrides to their team
+func applyPlayerTeamOverrides(playerStats []models.PlayerStat) {
+	for i := range playerStats {
+		name := strings.ToUpper(strings.Join(strings.Fields(playerStats[i].PlayerName), " "))
+		if team, found := PlayerTeamOverrides[name]; found {
+			playerStats[i].Team = team
+			log.Printf("Reassigned %s to team: %s", playerStats[i].PlayerName, team)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package parser
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestNormalizeTeamName(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"Sir James Pub Dos", "SIR JAMES PUB 2"},
+		{"SIR JAMES PUB", "SIR JAMES PUB"},
+		{"Bridge Inn #1", "BRIDGE INN 1"},
+		{"Harbor Hills 2", "HARBOR HILLS TOO"},
+		{"Eyes of the Hill", "HILLS HAS EYES"},
+		{"the hutch B", "THE HUTCH B"},
+		{"Redheads", "REDHEADS"},
+	}
+
+	for _, tt := range tests {
+		if got := NormalizeTeamName(tt.name); got != tt.want {
+			t.Errorf("NormalizeTeamName(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestSetTeamAliases(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "aliases.json")
+	data := `{"Red Heads": "REDHEADS", "Red Heads Two": "REDHEADS 2"}`
+	if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+	aliases, err := LoadTeamAliases(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defaults := teamAliases
+	SetTeamAliases(aliases)
+	defer SetTeamAliases(defaults)
+
+	for name, want := range map[string]string{
+		"red-heads":     "REDHEADS",
+		"Red Heads Two": "REDHEADS 2",
+		"Bridge Inn 1":  "BRIDGE INN 1",
+	} {
+		if got := NormalizeTeamName(name); got != want {
+			t.Errorf("NormalizeTeamName(%q) = %q, want %q", name, got, want)
+		}
+		if got := NormalizeTeamNameWith(name, aliases); got != want {
+			t.Errorf("NormalizeTeamNameWith(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
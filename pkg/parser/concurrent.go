@@ -0,0 +1,81 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"sort"
+	"sync"
+
+	"github.com/myusername/dart-statistic-scraper/pkg/models"
+	"github.com/myusername/dart-statistic-scraper/pkg/vfs"
+)
+
+// InputFS is the filesystem pages without HTML are read from. Point it at the
+// scraper's output filesystem to parse pages a dry run kept in memory.
+var InputFS vfs.FS = vfs.OS{}
+
+// Page is a downloaded standings page for a single week.
+// If HTML is empty the page is read from Path on InputFS.
+type Page struct {
+	Week int
+	Path string
+	HTML string
+}
+
+// ParsePagesConcurrent parses already-downloaded standings pages using at most
+// maxWorkers goroutines (runtime.NumCPU() when maxWorkers <= 0), each the way
+// ParseStandingsPage parses it with options. Results are ordered by week. Pages that can't be read are skipped and their errors are
+// returned together once all other pages have been parsed.
+func ParsePagesConcurrent(pages []Page, maxWorkers int, options PageOptions) ([]*models.WeeklyStats, error) {
+	if maxWorkers <= 0 {
+		maxWorkers = runtime.NumCPU()
+	}
+
+	results := make([]*models.WeeklyStats, len(pages))
+	errs := make([]error, len(pages))
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < maxWorkers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				results[i], errs[i] = parsePage(pages[i], options)
+			}
+		}()
+	}
+
+	for i := range pages {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	var weeklyStats []*models.WeeklyStats
+	for _, result := range results {
+		if result != nil {
+			weeklyStats = append(weeklyStats, result)
+		}
+	}
+	sort.SliceStable(weeklyStats, func(i, j int) bool {
+		return weeklyStats[i].Week < weeklyStats[j].Week
+	})
+
+	return weeklyStats, errors.Join(errs...)
+}
+
+// parsePage reads a page if needed and extracts its weekly stats
+func parsePage(page Page, options PageOptions) (*models.WeeklyStats, error) {
+	htmlContent := page.HTML
+	if htmlContent == "" {
+		content, err := vfs.ReadFile(InputFS, page.Path)
+		if err != nil {
+			return nil, fmt.Errorf("week %d: error reading %s: %w", page.Week, page.Path, err)
+		}
+		htmlContent = string(content)
+	}
+
+	return ParseStandingsPage(htmlContent, page.Week, page.Path, options)
+}
@@ -0,0 +1,299 @@
+package parser
+
+import "strings"
+
+// DecimalMode controls how decimal separators in PPD/MPR cells are read
+type DecimalMode int
+
+// Supported decimal modes
+const (
+	// DecimalAuto treats a comma as the decimal point when a cell has exactly
+	// one comma and no period (e.g. "24,35"), and a period otherwise
+	DecimalAuto DecimalMode = iota
+	// DecimalPeriod always treats the period as the decimal point ("24.35")
+	DecimalPeriod
+	// DecimalComma always treats the comma as the decimal point ("24,35"),
+	// ignoring periods used as thousands separators
+	DecimalComma
+)
+
+// Column identifies which PlayerStat field a stats column holds
+type Column int
+
+// Supported stats columns
+const (
+	ColumnPlayer Column = iota
+	ColumnSancPd
+	ColumnGames
+	ColumnWins
+	ColumnPPD
+	ColumnMPR
+	ColumnHatTricks
+	ColumnHighScore
+	ColumnHighCheckout
+	// ColumnRecord holds a "W-L" record such as "12-4", read as 12 wins out of 16 games
+	ColumnRecord
+	// ColumnDartsThrown holds the number of darts thrown, used to weight averages
+	ColumnDartsThrown
+	// ColumnPlusMinus holds a signed plus/minus or spread value
+	ColumnPlusMinus
+)
+
+// DefaultColumns is the column layout used by most standings pages
+var DefaultColumns = []Column{
+	ColumnPlayer,
+	ColumnSancPd,
+	ColumnGames,
+	ColumnWins,
+	ColumnPPD,
+	ColumnMPR,
+	ColumnHatTricks,
+	ColumnHighScore,
+	ColumnHighCheckout,
+}
+
+// RecordColumns is the layout used by pages that show a W-L record instead of
+// separate games and wins columns
+var RecordColumns = []Column{
+	ColumnPlayer,
+	ColumnSancPd,
+	ColumnRecord,
+	ColumnPPD,
+	ColumnMPR,
+	ColumnHatTricks,
+	ColumnHighScore,
+	ColumnHighCheckout,
+}
+
+// SectionMarkers delimit a stats section of a standings page
+type SectionMarkers struct {
+	// Start markers are tried in order to find the start of the section
+	Start []string
+	// End markers are tried in order to find the end of the section; the rest
+	// of the document is used when none are found
+	End []string
+}
+
+// DefaultSectionMarkers select the combined X01/Cricket section, falling back
+// to the X01 section
+var DefaultSectionMarkers = SectionMarkers{
+	Start: []string{
+		"Combined X01/Cricket games, sorted by Team + PPD:",
+		"All X01 games, sorted by PPD:",
+		"X01/Cricket games, sorted by Team",
+		"Combined X01/Cricket games",
+		"X01 games, sorted by PPD",
+	},
+	End: []string{
+		"Most Improved Players for week",
+	},
+}
+
+// X01SectionMarkers select only the X01 section, sorted by PPD
+var X01SectionMarkers = SectionMarkers{
+	Start: []string{
+		"All X01 games, sorted by PPD:",
+		"X01 games, sorted by PPD",
+	},
+	End: []string{
+		"Cricket games, sorted by",
+		"Most Improved Players for week",
+	},
+}
+
+// CricketSectionMarkers select only the Cricket section, sorted by MPR
+var CricketSectionMarkers = SectionMarkers{
+	Start: []string{
+		"All Cricket games, sorted by MPR:",
+		"Cricket games, sorted by MPR",
+	},
+	End: []string{
+		"X01 games, sorted by",
+		"Most Improved Players for week",
+	},
+}
+
+// CricketColumns is the layout of the Cricket section, which has no PPD or
+// X01 high score columns
+var CricketColumns = []Column{
+	ColumnPlayer,
+	ColumnSancPd,
+	ColumnGames,
+	ColumnWins,
+	ColumnMPR,
+	ColumnHatTricks,
+}
+
+// ParserConfig controls how standings pages are parsed
+type ParserConfig struct {
+	// Decimal selects how decimal separators in PPD/MPR values are handled
+	Decimal DecimalMode
+
+	// Columns lists the stats columns in the order they appear on the page.
+	// Divisions that don't track some stats (e.g. HighScore/HighCheckout)
+	// can omit them so those fields are never read.
+	Columns []Column
+
+	// Markers locate the player stats section to parse
+	Markers SectionMarkers
+
+	// SplitTables enables the layout where player names and ratings are in one
+	// table and their stats in a second table alongside, matched row by row
+	SplitTables bool
+
+	// KeepDuplicates leaves repeated rows for the same player as they are
+	// instead of merging them with DedupePlayerStats
+	KeepDuplicates bool
+}
+
+// DefaultParserConfig returns the configuration used by ExtractPlayerStats
+func DefaultParserConfig() ParserConfig {
+	return ParserConfig{
+		Decimal: DecimalAuto,
+		Columns: DefaultColumns,
+		Markers: DefaultSectionMarkers,
+	}
+}
+
+// valueColumns returns the columns that follow the player name and rating
+func (c ParserConfig) valueColumns() []Column {
+	var columns []Column
+	for _, column := range c.Columns {
+		if column != ColumnPlayer && column != ColumnSancPd {
+			columns = append(columns, column)
+		}
+	}
+	return columns
+}
+
+// statsHeader returns the header that marks a table as holding player stats:
+// "PPD", or "MPR" for layouts without a PPD column such as CricketColumns
+func (c ParserConfig) statsHeader() string {
+	for _, column := range c.Columns {
+		if column == ColumnPPD {
+			return "PPD"
+		}
+	}
+	return "MPR"
+}
+
+// minRowCells returns the fewest cells a table row needs to be read as a player
+func (c ParserConfig) minRowCells() int {
+	if len(c.Columns) < 7 {
+		return len(c.Columns)
+	}
+	return 7
+}
+
+// playerColumnIndex returns the position of the player name column, 0 when
+// the layout has none
+func (c ParserConfig) playerColumnIndex() int {
+	for i, column := range c.Columns {
+		if column == ColumnPlayer {
+			return i
+		}
+	}
+	return 0
+}
+
+// withHeaders returns a copy of the configuration whose column layout follows
+// a header row. When every header names a known column (see headerColumns)
+// and they are in a different order than the configured layout, the columns
+// are read in header order. Otherwise the configured layout is kept, also
+// covering optional columns such as "+/-" or "Spread", which are inserted at
+// their header position.
+func (c ParserConfig) withHeaders(headers []string) ParserConfig {
+	if layout, ok := headerLayout(headers); ok && !isColumnPrefix(layout, c.Columns) {
+		c.Columns = layout
+		return c
+	}
+
+	columns := append([]Column(nil), c.Columns...)
+	for i, header := range headers {
+		if !isPlusMinusHeader(header) || i > len(columns) {
+			continue
+		}
+		columns = append(columns[:i], append([]Column{ColumnPlusMinus}, columns[i:]...)...)
+	}
+	c.Columns = columns
+	return c
+}
+
+// headerColumns maps lowercased header names to the columns they label
+var headerColumns = map[string]Column{
+	"player":        ColumnPlayer,
+	"name":          ColumnPlayer,
+	"sancpd":        ColumnSancPd,
+	"sanc pd":       ColumnSancPd,
+	"rating":        ColumnSancPd,
+	"games":         ColumnGames,
+	"gp":            ColumnGames,
+	"wins":          ColumnWins,
+	"won":           ColumnWins,
+	"ppd":           ColumnPPD,
+	"mpr":           ColumnMPR,
+	"hat":           ColumnHatTricks,
+	"ht":            ColumnHatTricks,
+	"hat tricks":    ColumnHatTricks,
+	"hsttons":       ColumnHighScore,
+	"hstton":        ColumnHighScore,
+	"high score":    ColumnHighScore,
+	"hstout":        ColumnHighCheckout,
+	"high checkout": ColumnHighCheckout,
+	"w-l":           ColumnRecord,
+	"record":        ColumnRecord,
+	"darts":         ColumnDartsThrown,
+	"+/-":           ColumnPlusMinus,
+	"+-":            ColumnPlusMinus,
+	"spread":        ColumnPlusMinus,
+}
+
+// headerLayout returns the column layout named by a header row, reporting
+// false unless every header is known, none repeats, and both a player column
+// and a PPD or MPR column are present
+func headerLayout(headers []string) ([]Column, bool) {
+	var layout []Column
+	seen := make(map[Column]bool)
+	for _, header := range headers {
+		column, found := headerColumns[strings.ToLower(strings.Join(strings.Fields(header), " "))]
+		if !found || seen[column] {
+			return nil, false
+		}
+		seen[column] = true
+		layout = append(layout, column)
+	}
+	return layout, seen[ColumnPlayer] && (seen[ColumnPPD] || seen[ColumnMPR])
+}
+
+// isColumnPrefix reports whether layout matches the start of columns
+func isColumnPrefix(layout, columns []Column) bool {
+	if len(layout) > len(columns) {
+		return false
+	}
+	for i, column := range layout {
+		if columns[i] != column {
+			return false
+		}
+	}
+	return true
+}
+
+// isPlusMinusHeader reports whether a header labels a plus/minus column
+func isPlusMinusHeader(header string) bool {
+	header = strings.ToLower(strings.TrimSpace(header))
+	return header == "+/-" || header == "+-" || header == "spread"
+}
+
+// normalizeDecimal rewrites a decimal value so that strconv.ParseFloat can read it
+func normalizeDecimal(s string, mode DecimalMode) string {
+	switch mode {
+	case DecimalComma:
+		s = strings.ReplaceAll(s, ".", "")
+		return strings.Replace(s, ",", ".", 1)
+	case DecimalAuto:
+		if strings.Count(s, ",") == 1 && !strings.Contains(s, ".") {
+			return strings.Replace(s, ",", ".", 1)
+		}
+	}
+	return s
+}
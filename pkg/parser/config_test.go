@@ -0,0 +1,53 @@
+package parser
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+func TestHeaderLayout(t *testing.T) {
+	tests := []struct {
+		headers []string
+		want    []Column
+		wantOK  bool
+	}{
+		{[]string{"PPD", "Player", "Games", "Wins", "MPR"}, []Column{ColumnPPD, ColumnPlayer, ColumnGames, ColumnWins, ColumnMPR}, true},
+		{[]string{"Name", "Sanc  Pd", "W-L", "MPR"}, []Column{ColumnPlayer, ColumnSancPd, ColumnRecord, ColumnMPR}, true},
+		// Unknown or repeated headers, or no stats to rank by, fall back to positions
+		{[]string{"Player", "Games", "Shoe Size", "PPD"}, nil, false},
+		{[]string{"Player", "PPD", "PPD"}, nil, false},
+		{[]string{"Player", "Games", "Wins"}, []Column{ColumnPlayer, ColumnGames, ColumnWins}, false},
+	}
+
+	for _, tt := range tests {
+		got, ok := headerLayout(tt.headers)
+		if ok != tt.wantOK || (ok && !reflect.DeepEqual(got, tt.want)) {
+			t.Errorf("headerLayout(%q) = %v, %v; want %v, %v", tt.headers, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestExtractPlayerStatsFromTableShuffledColumns(t *testing.T) {
+	page := `<table>
+<tr><th>PPD</th><th>Wins</th><th>Player</th><th>HSTout</th><th>Games</th><th>MPR</th><th>SancPd</th><th>High Score</th><th>Hat</th></tr>
+<tr><td colspan="9">REDHEADS</td></tr>
+<tr><td>24.50</td><td>6</td><td>JOHN SMITH</td><td>96</td><td>10</td><td>2.10</td><td>A</td><td>140</td><td>1</td></tr>
+</table>`
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(page))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	playerStats, _ := extractPlayerStatsFromTable(doc, "", DefaultParserConfig())
+	if len(playerStats) != 1 {
+		t.Fatalf("extractPlayerStatsFromTable() found %d players, want 1: %+v", len(playerStats), playerStats)
+	}
+	got := playerStats[0]
+	if got.PlayerName != "JOHN SMITH" || got.SancPd != "A" || got.GamesPlayed != 10 || got.GamesWon != 6 ||
+		got.PPD != 24.5 || got.MPR != 2.1 || got.HatTricks != 1 || got.HighScore != 140 || got.HighCheckout != 96 {
+		t.Errorf("player from shuffled columns = %+v", got)
+	}
+}
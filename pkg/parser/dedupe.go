@@ -0,0 +1,79 @@
+package parser
+
+import (
+	"log"
+	"strings"
+
+	"github.com/myusername/dart-statistic-scraper/pkg/models"
+)
+
+// DedupePlayerStats merges rows for the same player on the same team, matching
+// normalized names. When one row holds only X01 stats (PPD) and the other only
+// Cricket stats (MPR) they are different game types and are combined, summing
+// games, wins and hat tricks; otherwise the row with more games is kept. Rows
+// stay in the order each player was first listed.
+func DedupePlayerStats(playerStats []models.PlayerStat) []models.PlayerStat {
+	index := make(map[string]int)
+	var deduped []models.PlayerStat
+
+	for _, player := range playerStats {
+		key := strings.ToUpper(strings.Join(strings.Fields(player.PlayerName), " ")) + "|" + NormalizeTeamName(player.Team)
+		i, found := index[key]
+		if !found {
+			index[key] = len(deduped)
+			deduped = append(deduped, player)
+			continue
+		}
+
+		existing := deduped[i]
+		if isSingleGameType(existing) && isSingleGameType(player) && (existing.PPD > 0) != (player.PPD > 0) {
+			deduped[i] = combineGameTypes(existing, player)
+			log.Printf("Combined X01 and Cricket rows for %s (%s)", player.PlayerName, player.Team)
+		} else {
+			if player.GamesPlayed > existing.GamesPlayed {
+				deduped[i] = player
+			}
+			log.Printf("Dropped duplicate row for %s (%s)", player.PlayerName, player.Team)
+		}
+	}
+
+	return deduped
+}
+
+// isSingleGameType reports whether a row has stats for only one of X01 and Cricket
+func isSingleGameType(player models.PlayerStat) bool {
+	return (player.PPD > 0) != (player.MPR > 0)
+}
+
+// combineGameTypes merges an X01-only row with a Cricket-only row
+func combineGameTypes(a, b models.PlayerStat) models.PlayerStat {
+	merged := a
+	merged.GamesPlayed += b.GamesPlayed
+	merged.GamesWon += b.GamesWon
+	merged.HatTricks += b.HatTricks
+	merged.DartsThrown += b.DartsThrown
+	merged.PlusMinus += b.PlusMinus
+	if merged.PPD == 0 {
+		merged.PPD = b.PPD
+	}
+	if merged.MPR == 0 {
+		merged.MPR = b.MPR
+	}
+	if b.HighScore > merged.HighScore {
+		merged.HighScore = b.HighScore
+	}
+	if b.HighCheckout > merged.HighCheckout {
+		merged.HighCheckout = b.HighCheckout
+	}
+	if merged.SancPd == "" {
+		merged.SancPd = b.SancPd
+		merged.Rating = b.Rating
+	}
+	if merged.Opponent == "" {
+		merged.Opponent = b.Opponent
+	}
+	if merged.SeedNumber == 0 {
+		merged.SeedNumber = b.SeedNumber
+	}
+	return merged
+}
@@ -0,0 +1,53 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/myusername/dart-statistic-scraper/pkg/models"
+)
+
+func TestDedupePlayerStats(t *testing.T) {
+	playerStats := []models.PlayerStat{
+		{PlayerName: "JOHN SMITH", Team: "BRIDGE INN 1", GamesPlayed: 6, GamesWon: 3, PPD: 24.5, MPR: 2.1},
+		{PlayerName: "MARY JONES", Team: "REDHEADS", GamesPlayed: 4, GamesWon: 2, PPD: 20.1},
+		// The same player listed again, with a different spelling of the team
+		{PlayerName: "John  Smith", Team: "Bridge Inn #1", GamesPlayed: 10, GamesWon: 6, PPD: 25.0, MPR: 2.3},
+		// An X01-only row and a Cricket-only row are different game types
+		{PlayerName: "MARY JONES", Team: "REDHEADS", GamesPlayed: 3, GamesWon: 1, MPR: 1.8, HatTricks: 1},
+		// The same name on another team is another player
+		{PlayerName: "JOHN SMITH", Team: "REDHEADS", GamesPlayed: 2, GamesWon: 1, PPD: 15.0, MPR: 1.2},
+	}
+
+	want := []models.PlayerStat{
+		{PlayerName: "John  Smith", Team: "Bridge Inn #1", GamesPlayed: 10, GamesWon: 6, PPD: 25.0, MPR: 2.3},
+		{PlayerName: "MARY JONES", Team: "REDHEADS", GamesPlayed: 7, GamesWon: 3, PPD: 20.1, MPR: 1.8, HatTricks: 1},
+		{PlayerName: "JOHN SMITH", Team: "REDHEADS", GamesPlayed: 2, GamesWon: 1, PPD: 15.0, MPR: 1.2},
+	}
+	if got := DedupePlayerStats(playerStats); !reflect.DeepEqual(got, want) {
+		t.Errorf("DedupePlayerStats() =\n%+v\nwant\n%+v", got, want)
+	}
+}
+
+func TestExtractPlayerStatsMergesDuplicateRows(t *testing.T) {
+	page := `<html><body>
+<p>Combined X01/Cricket games, sorted by Team + PPD:</p>
+<table>
+<tr><th>Player</th><th>SancPd</th><th>Games</th><th>Wins</th><th>PPD</th><th>MPR</th><th>Hat</th><th>High</th><th>Out</th></tr>
+<tr><td colspan="9">REDHEADS</td></tr>
+<tr><td>JOHN SMITH</td><td>A</td><td>10</td><td>6</td><td>24.50</td><td>2.10</td><td>1</td><td>140</td><td>96</td></tr>
+<tr><td>JOHN SMITH</td><td>A</td><td>10</td><td>6</td><td>24.50</td><td>2.10</td><td>1</td><td>140</td><td>96</td></tr>
+</table>
+<p>Most Improved Players for week</p>
+</body></html>`
+
+	if playerStats, _ := ExtractPlayerStats(page); len(playerStats) != 1 {
+		t.Errorf("ExtractPlayerStats() kept %d rows for one player, want 1", len(playerStats))
+	}
+
+	config := DefaultParserConfig()
+	config.KeepDuplicates = true
+	if playerStats, _ := ExtractPlayerStatsWithConfig(page, config); len(playerStats) != 2 {
+		t.Errorf("ExtractPlayerStatsWithConfig() with KeepDuplicates kept %d rows, want 2", len(playerStats))
+	}
+}
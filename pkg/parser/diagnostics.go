@@ -0,0 +1,63 @@
+package parser
+
+import (
+	"fmt"
+
+	"github.com/myusername/dart-statistic-scraper/pkg/models"
+)
+
+// ParseResult holds everything extracted from the player stats section of a page
+type ParseResult struct {
+	PlayerStats []models.PlayerStat
+	TeamStats   []models.TeamStat
+	// Diagnostics lists the cells that couldn't be parsed and were read as zero
+	Diagnostics []CellDiagnostic
+}
+
+// CellDiagnostic records a stats cell whose value couldn't be parsed
+type CellDiagnostic struct {
+	Player string
+	Team   string
+	Column Column
+	Raw    string
+}
+
+func (d CellDiagnostic) String() string {
+	return fmt.Sprintf("%s (%s): unparseable %s value %q", d.Player, d.Team, d.Column, d.Raw)
+}
+
+// columnNames are the display names of the stats columns
+var columnNames = map[Column]string{
+	ColumnPlayer:       "Player",
+	ColumnSancPd:       "SancPd",
+	ColumnGames:        "Games",
+	ColumnWins:         "Wins",
+	ColumnPPD:          "PPD",
+	ColumnMPR:          "MPR",
+	ColumnHatTricks:    "HatTricks",
+	ColumnHighScore:    "HighScore",
+	ColumnHighCheckout: "HighCheckout",
+	ColumnRecord:       "Record",
+	ColumnDartsThrown:  "DartsThrown",
+	ColumnPlusMinus:    "PlusMinus",
+}
+
+func (c Column) String() string {
+	if name, found := columnNames[c]; found {
+		return name
+	}
+	return fmt.Sprintf("Column(%d)", int(c))
+}
+
+// assignCell assigns a cell to a player and records a diagnostic if it
+// couldn't be parsed
+func assignCell(playerStat *models.PlayerStat, column Column, raw string, config ParserConfig, diagnostics *[]CellDiagnostic) {
+	if !assignColumn(playerStat, column, raw, config) {
+		*diagnostics = append(*diagnostics, CellDiagnostic{
+			Player: playerStat.PlayerName,
+			Team:   playerStat.Team,
+			Column: column,
+			Raw:    raw,
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package parser
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/myusername/dart-statistic-scraper/pkg/models"
+)
+
+// columnSpan is the character range a header label occupies in a fixed-width line
+type columnSpan struct {
+	start, end int
+}
+
+// fixedWidthLayout holds the column spans read from a fixed-width header line,
+// as found in <pre> standings dumps
+type fixedWidthLayout []columnSpan
+
+// newFixedWidthLayout reads the column spans from a header line such as
+// "Player          SancPd Games Wins   PPD   MPR Hat  HS  HC". Labels made of
+// several words ("Hat Tricks") are merged when that's needed to get one span
+// per column. It returns nil when the header doesn't match the column count.
+func newFixedWidthLayout(header string, columns int) fixedWidthLayout {
+	var layout fixedWidthLayout
+	for _, loc := range regexp.MustCompile(`\S+`).FindAllStringIndex(header, -1) {
+		layout = append(layout, columnSpan{start: loc[0], end: loc[1]})
+	}
+	if len(layout) == columns {
+		return layout
+	}
+
+	// Merge labels separated by a single space
+	var merged fixedWidthLayout
+	for _, span := range layout {
+		if n := len(merged); n > 0 && span.start-merged[n-1].end == 1 {
+			merged[n-1].end = span.end
+			continue
+		}
+		merged = append(merged, span)
+	}
+	if len(merged) == columns {
+		return merged
+	}
+	return nil
+}
+
+// split assigns each value in a line to the column it lines up with, leaving
+// blank columns empty so later values don't shift. Values overlapping a label
+// belong to that column; values in the gap between two labels go left when they
+// are text (left-aligned names) and right when they are numbers (right-aligned).
+// Several values in one column, such as a first and last name, are joined.
+func (l fixedWidthLayout) split(line string) []string {
+	cells := make([]string, len(l))
+	for _, loc := range regexp.MustCompile(`\S+`).FindAllStringIndex(line, -1) {
+		value := line[loc[0]:loc[1]]
+		column := l.columnFor(loc[0], loc[1], isNumeric(sanitizeNumberString(value)))
+		if cells[column] != "" {
+			cells[column] += " "
+		}
+		cells[column] += value
+	}
+	return cells
+}
+
+// columnFor returns the index of the column a value spanning [start, end) belongs to
+func (l fixedWidthLayout) columnFor(start, end int, numeric bool) int {
+	for i, span := range l {
+		if start < span.end && end > span.start {
+			return i
+		}
+	}
+
+	for i, span := range l {
+		if end <= span.start {
+			if i == 0 || numeric {
+				return i
+			}
+			return i - 1
+		}
+	}
+	return len(l) - 1
+}
+
+// parseFixedWidthLine parses a player line from a fixed-width dump using the
+// header layout, so blank cells in the middle of the line stay empty
+func parseFixedWidthLine(line string, layout fixedWidthLayout, config ParserConfig) (models.PlayerStat, []CellDiagnostic) {
+	var playerStat models.PlayerStat
+	var diagnostics []CellDiagnostic
+
+	cells := layout.split(line)
+	values := 0
+	for i, column := range config.Columns {
+		if column != ColumnPlayer && column != ColumnSancPd && cells[i] != "" {
+			values++
+		}
+	}
+
+	// Require a name and enough values to rule out stray text
+	if strings.TrimSpace(cells[0]) == "" || values < 3 {
+		return playerStat, nil
+	}
+
+	for i, column := range config.Columns {
+		assignCell(&playerStat, column, cells[i], config, &diagnostics)
+	}
+	return playerStat, diagnostics
+}
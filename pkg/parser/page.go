@@ -0,0 +1,90 @@
+package parser
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/myusername/dart-statistic-scraper/pkg/models"
+)
+
+// PageOptions describes how ParseStandingsPage fills in what a standings page
+// doesn't state itself
+type PageOptions struct {
+	// Config is the parser configuration, usually DefaultParserConfig()
+	Config ParserConfig
+	// Division tags the week and limits opponents to the division's matchups
+	Division string
+	// Schedules supply each player's opponent, and the date when the page has none
+	Schedules []models.MatchSchedule
+	// CurrentWeek is checked for stale standings when set
+	CurrentWeek int
+	// Finish, if set, adjusts each parsed week before it is returned, e.g. to
+	// drop excluded players or tag segments
+	Finish func(weeklyStats *models.WeeklyStats) *models.WeeklyStats
+}
+
+// ParseStandingsPage parses one week's standings page into weekly stats. The
+// week comes from the caller, or from the page when week is 0, and source
+// names the page in log messages. Pages malformed badly enough to panic the
+// parser return an error.
+func ParseStandingsPage(htmlContent string, week int, source string, options PageOptions) (*models.WeeklyStats, error) {
+	// Cross-check the week against the page body, preferring the page's date
+	pageWeek, date := ExtractWeekAndDate(htmlContent)
+	if week == 0 {
+		week = pageWeek
+	} else if pageWeek > 0 && pageWeek != week {
+		log.Printf("Warning: page states week %d but URL indicates week %d: %s", pageWeek, week, source)
+	}
+	if week == 0 {
+		return nil, fmt.Errorf("%s: no week number in URL or page", source)
+	}
+	scheduledDate := scheduleDate(week, options.Schedules)
+	if date == "" {
+		date = scheduledDate
+	}
+
+	// Make sure the page for the current week has actually been updated
+	if options.CurrentWeek > 0 && week == options.CurrentWeek {
+		if err := CheckStaleStandings(htmlContent, week, scheduledDate); err != nil {
+			log.Printf("Warning: %v: %s", err, source)
+		}
+	}
+
+	playerStats, teamStats, err := ExtractPlayerStatsSafe(htmlContent, options.Config, week, source)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing standings page: %w", err)
+	}
+
+	// Add opponent information to each player, falling back to every
+	// division's matchups when the schedule names its divisions differently
+	for i := range playerStats {
+		opponent := FindOpponentInDivision(playerStats[i].Team, week, options.Division, options.Schedules)
+		if opponent == "Unknown" && options.Division != "" {
+			opponent = FindOpponent(playerStats[i].Team, week, options.Schedules)
+		}
+		playerStats[i].Opponent = opponent
+	}
+
+	weeklyStats := &models.WeeklyStats{
+		Week:        week,
+		Division:    options.Division,
+		Date:        date,
+		Segment:     ExtractSegment(htmlContent),
+		PlayerStats: playerStats,
+		TeamStats:   teamStats,
+	}
+	if options.Finish != nil {
+		weeklyStats = options.Finish(weeklyStats)
+	}
+	return weeklyStats, nil
+}
+
+// scheduleDate returns the scheduled date for a week, or an empty string if unknown
+func scheduleDate(week int, schedules []models.MatchSchedule) string {
+	for _, schedule := range schedules {
+		if schedule.Week == week {
+			return schedule.Date
+		}
+	}
+	return ""
+}
@@ -0,0 +1,102 @@
+package parser
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/myusername/dart-statistic-scraper/pkg/models"
+	"github.com/myusername/dart-statistic-scraper/pkg/vfs"
+)
+
+// standingsPage returns a standings page with a heading and one team and player
+func standingsPage(heading, team, player string) string {
+	return fmt.Sprintf(`<html><body>
+<h2>%s</h2>
+<p>Combined X01/Cricket games, sorted by Team + PPD:</p>
+<table>
+<tr><th>Player</th><th>SancPd</th><th>Games</th><th>Wins</th><th>PPD</th><th>MPR</th><th>Hat</th><th>High</th><th>Out</th></tr>
+<tr><td colspan="9">%s</td></tr>
+<tr><td>%s</td><td>A</td><td>10</td><td>6</td><td>24.50</td><td>2.10</td><td>1</td><td>140</td><td>96</td></tr>
+</table>
+</body></html>`, heading, team, player)
+}
+
+func TestParseStandingsPage(t *testing.T) {
+	schedules := []models.MatchSchedule{
+		{Week: 3, Date: "October 5, 2024", HomeTeam: "HARBOR HILLS", AwayTeam: "REDHEADS", Division: "SUN1"},
+		{Week: 3, Date: "October 5, 2024", HomeTeam: "HARBOR HILLS", AwayTeam: "BULLSEYES", Division: "SUN2"},
+	}
+	finished := 0
+	options := PageOptions{
+		Config:    DefaultParserConfig(),
+		Division:  "SUN2",
+		Schedules: schedules,
+		Finish: func(weeklyStats *models.WeeklyStats) *models.WeeklyStats {
+			finished++
+			return weeklyStats
+		},
+	}
+
+	// The week comes from the page when the caller doesn't know it, and the
+	// date from the schedule when the page doesn't state one
+	got, err := ParseStandingsPage(standingsPage("Week 3 Standings", "HARBOR HILLS", "JOHN SMITH"), 0, "week3.html", options)
+	if err != nil {
+		t.Fatalf("ParseStandingsPage: %v", err)
+	}
+	if got.Week != 3 || got.Date != "October 5, 2024" || got.Division != "SUN2" {
+		t.Errorf("week %d, date %q, division %q; want 3, October 5, 2024, SUN2", got.Week, got.Date, got.Division)
+	}
+	if len(got.PlayerStats) != 1 || got.PlayerStats[0].Opponent != "BULLSEYES" {
+		t.Errorf("players = %+v, want JOHN SMITH playing BULLSEYES", got.PlayerStats)
+	}
+	if finished != 1 {
+		t.Errorf("Finish called %d times, want 1", finished)
+	}
+
+	// A division the schedule doesn't name still finds the matchup
+	options.Division = "Sunday 2"
+	got, err = ParseStandingsPage(standingsPage("Week 3 - October 6, 2024", "HARBOR HILLS", "JOHN SMITH"), 3, "week3.html", options)
+	if err != nil {
+		t.Fatalf("ParseStandingsPage: %v", err)
+	}
+	if got.Date != "October 6, 2024" || got.PlayerStats[0].Opponent != "REDHEADS" {
+		t.Errorf("date %q, opponent %q; want the page's date and REDHEADS", got.Date, got.PlayerStats[0].Opponent)
+	}
+
+	if _, err := ParseStandingsPage(standingsPage("Standings", "HARBOR HILLS", "JOHN SMITH"), 0, "standings.html", options); err == nil {
+		t.Error("ParseStandingsPage without a week = nil error, want an error")
+	}
+}
+
+func TestParsePagesConcurrentReadsInputFS(t *testing.T) {
+	fsys := vfs.NewMemFS()
+	oldFS := InputFS
+	InputFS = fsys
+	defer func() { InputFS = oldFS }()
+
+	for week := 1; week <= 3; week++ {
+		page := standingsPage(fmt.Sprintf("Week %d", week), "HARBOR HILLS", "JOHN SMITH")
+		if err := vfs.WriteFile(fsys, fmt.Sprintf("html/standings_week_%d.html", week), []byte(page)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	pages := []Page{
+		{Week: 3, Path: "html/standings_week_3.html"},
+		{Week: 1, Path: "html/standings_week_1.html"},
+		{Week: 2, Path: "html/standings_week_2.html"},
+		{Week: 4, Path: "html/standings_week_4.html"},
+	}
+
+	weeks, err := ParsePagesConcurrent(pages, 2, PageOptions{Config: DefaultParserConfig()})
+	if err == nil {
+		t.Error("ParsePagesConcurrent with a missing page = nil error, want an error")
+	}
+	if len(weeks) != 3 {
+		t.Fatalf("ParsePagesConcurrent returned %d weeks, want 3", len(weeks))
+	}
+	for i, weeklyStats := range weeks {
+		if weeklyStats.Week != i+1 || len(weeklyStats.PlayerStats) != 1 {
+			t.Errorf("result %d = week %d with %d players, want week %d with 1", i, weeklyStats.Week, len(weeklyStats.PlayerStats), i+1)
+		}
+	}
+}
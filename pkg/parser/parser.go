@@ -2,9 +2,11 @@
 package parser
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"log"
+	"os"
 	"regexp"
 	"strconv"
 	"strings"
@@ -15,17 +17,36 @@ import (
 	"github.com/myusername/dart-statistic-scraper/pkg/models"
 )
 
+// ErrSectionNotFound is returned when none of the start markers appear in a page
+var ErrSectionNotFound = errors.New("no suitable start marker found in HTML")
+
 // ReadPDFText reads a PDF file and returns its text content
 func ReadPDFText(pdfPath string) (string, error) {
 	// Open the PDF file
-	f, r, err := pdf.Open(pdfPath)
+	f, err := os.Open(pdfPath)
 	if err != nil {
 		return "", fmt.Errorf("error opening PDF: %w", err)
 	}
 	defer f.Close()
 
+	info, err := f.Stat()
+	if err != nil {
+		return "", fmt.Errorf("error opening PDF: %w", err)
+	}
+
+	return ReadPDFFromReader(f, info.Size())
+}
+
+// ReadPDFFromReader extracts the plain text of a PDF held in r, which is size
+// bytes long, such as a PDF downloaded into memory
+func ReadPDFFromReader(r io.ReaderAt, size int64) (string, error) {
+	reader, err := pdf.NewReader(r, size)
+	if err != nil {
+		return "", fmt.Errorf("error opening PDF: %w", err)
+	}
+
 	// Extract plain text from the PDF
-	plainText, err := r.GetPlainText()
+	plainText, err := reader.GetPlainText()
 	if err != nil {
 		return "", fmt.Errorf("error extracting text from PDF: %w", err)
 	}
@@ -50,11 +71,27 @@ func ExtractScheduleFromText(text string) []models.MatchSchedule {
 	weekDateRegex := regexp.MustCompile(`Week\s*(\d+)\s*-\s*(\w+\s*\d+\s*,\s*\d{4})`)
 
 	// Regular expression to match team matchups
-	// Looking for patterns like "TEAM A vs TEAM B" or "TEAM A @ TEAM B"
-	matchupRegex := regexp.MustCompile(`([A-Z\s&']+)\s*(?:vs\.?|@|at)\s*([A-Z\s&']+)`)
+	// Looking for patterns like "TEAM A vs TEAM B" or "TEAM A @ TEAM B", optionally
+	// naming the pairings in parentheses: "TEAM A (SMITH/JONES) vs TEAM B (DOE/ROE)"
+	matchupRegex := regexp.MustCompile(`([A-Z\s&']+)(?:\(([^)]*)\))?\s*(?:vs\.?|@|at)\s*([A-Z\s&']+)(?:\(([^)]*)\))?`)
+
+	// Lines with mixed-case team names or digits, like "Bridge Inn 1 vs Sir James
+	// Pub 2" or "Harbor Hills @ Redheads", hold a single matchup and are matched
+	// as a whole; their team names are normalized
+	mixedMatchupRegex := regexp.MustCompile(`^([A-Za-z0-9][A-Za-z0-9\s&'.]*?)\s*(?:\(([^)]*)\))?(?:\s+(?:vs\.?|at)\s+|\s*@\s*)([A-Za-z0-9][A-Za-z0-9\s&'.]*?)\s*(?:\(([^)]*)\))?$`)
+
+	// Regular expressions to spell BYE consistently and to match lines giving a
+	// team a BYE, like "THE HUTCH - BYE"
+	byeWordRegex := regexp.MustCompile(`\b(?:Bye|bye)\b`)
+	byeRegex := regexp.MustCompile(`^([A-Za-z0-9][A-Za-z0-9\s&'.]*?)\s*[-:]?\s*\bBYE\b`)
+
+	// Regular expression to match division headings, like "Division: Monday A",
+	// which apply to the matchups that follow
+	divisionRegex := regexp.MustCompile(`^(?i:division)\s*[:-]?\s*([A-Za-z0-9][A-Za-z0-9\s&'.]*)$`)
 
 	currentWeek := 0
 	currentDate := ""
+	currentDivision := ""
 
 	for _, line := range lines {
 		line = strings.TrimSpace(line)
@@ -71,19 +108,66 @@ func ExtractScheduleFromText(text string) []models.MatchSchedule {
 			}
 		}
 
+		// Check for a division heading
+		if divisionMatch := divisionRegex.FindStringSubmatch(line); len(divisionMatch) > 1 {
+			currentDivision = strings.TrimSpace(divisionMatch[1])
+			log.Printf("Found division %s", currentDivision)
+			continue
+		}
+
+		// First, check for BYE entries
+		line = byeWordRegex.ReplaceAllString(line, ByeTeam)
+		byeMatch := byeRegex.FindStringSubmatch(line)
+		if len(byeMatch) > 1 && currentWeek > 0 {
+			team := strings.TrimSpace(byeMatch[1])
+			if hasMixedCaseTeams(team) {
+				team = NormalizeTeamName(team)
+			}
+			if team != "" && !isBye(team) {
+				// Create match schedule entry with BYE as the away team
+				schedule := models.MatchSchedule{
+					Week:     currentWeek,
+					Date:     currentDate,
+					HomeTeam: team,
+					AwayTeam: ByeTeam,
+					Division: currentDivision,
+				}
+				if parsedDate, err := ParseMatchDate(currentDate); err == nil {
+					schedule.ParsedDate = parsedDate
+				}
+				schedules = append(schedules, schedule)
+				log.Printf("Week %d: %s vs BYE", currentWeek, team)
+				continue
+			}
+		}
+
 		// Check if line contains matchup information
 		matchupMatches := matchupRegex.FindAllStringSubmatch(line, -1)
+		mixedCase := hasMixedCaseTeams(line)
+		if mixedCase {
+			matchupMatches = mixedMatchupRegex.FindAllStringSubmatch(line, -1)
+		}
 		for _, match := range matchupMatches {
-			if len(match) > 2 && currentWeek > 0 {
+			if len(match) > 4 && currentWeek > 0 {
 				homeTeam := strings.TrimSpace(match[1])
-				awayTeam := strings.TrimSpace(match[2])
+				awayTeam := strings.TrimSpace(match[3])
+				if mixedCase {
+					homeTeam = NormalizeTeamName(homeTeam)
+					awayTeam = NormalizeTeamName(awayTeam)
+				}
 
 				// Create match schedule entry
 				schedule := models.MatchSchedule{
-					Week:     currentWeek,
-					Date:     currentDate,
-					HomeTeam: homeTeam,
-					AwayTeam: awayTeam,
+					Week:         currentWeek,
+					Date:         currentDate,
+					HomeTeam:     homeTeam,
+					AwayTeam:     awayTeam,
+					HomeSubMatch: strings.TrimSpace(match[2]),
+					AwaySubMatch: strings.TrimSpace(match[4]),
+					Division:     currentDivision,
+				}
+				if parsedDate, err := ParseMatchDate(currentDate); err == nil {
+					schedule.ParsedDate = parsedDate
 				}
 
 				schedules = append(schedules, schedule)
@@ -95,6 +179,21 @@ func ExtractScheduleFromText(text string) []models.MatchSchedule {
 	return schedules
 }
 
+// matchupSeparatorRegex matches the words separating teams in a matchup line,
+// and subMatchRegex the parenthesized pairings, neither of which are team names
+var (
+	matchupSeparatorRegex = regexp.MustCompile(`\s(?:vs\.?|at)\s`)
+	subMatchRegex         = regexp.MustCompile(`\([^)]*\)`)
+)
+
+// hasMixedCaseTeams reports whether a schedule line's team names contain
+// lowercase letters or digits, which the all-caps matchup pattern can't read
+func hasMixedCaseTeams(line string) bool {
+	teams := subMatchRegex.ReplaceAllString(line, " ")
+	teams = matchupSeparatorRegex.ReplaceAllString(" "+teams+" ", " ")
+	return strings.ContainsAny(teams, "abcdefghijklmnopqrstuvwxyz0123456789")
+}
+
 // ParseScheduleManually creates a hardcoded schedule based on known patterns
 // This is a fallback in case the PDF parsing doesn't work properly
 func ParseScheduleManually() []models.MatchSchedule {
@@ -150,18 +249,53 @@ func ParseScheduleManually() []models.MatchSchedule {
 	return schedules
 }
 
-// FindOpponent returns the opponent team for a given team in a specific week
+// OpponentSubMatches makes FindOpponent return the opposing pairing's sub-match
+// label when the schedule has one, rather than the opposing team
+var OpponentSubMatches = true
+
+// ByeTeam is the opponent of a team that doesn't play in a week
+const ByeTeam = "BYE"
+
+// isBye reports whether a schedule entry's team is a BYE
+func isBye(team string) bool {
+	return strings.EqualFold(strings.TrimSpace(team), ByeTeam)
+}
+
+// FindOpponent returns the opponent team for a given team in a specific week,
+// or the opposing pairing when the schedule names one and OpponentSubMatches is set
 func FindOpponent(team string, week int, schedules []models.MatchSchedule) string {
+	return FindOpponentInDivision(team, week, "", schedules)
+}
+
+// FindOpponentInDivision is like FindOpponent but only considers matchups in
+// the given division, for leagues whose divisions reuse team names. Matchups
+// without a division always count, and an empty division matches them all.
+func FindOpponentInDivision(team string, week int, division string, schedules []models.MatchSchedule) string {
 	for _, schedule := range schedules {
+		if division != "" && schedule.Division != "" && !strings.EqualFold(schedule.Division, division) {
+			continue
+		}
 		if schedule.Week == week {
 			// Normalize team name for comparison
 			normTeam := NormalizeTeamName(team)
 			normHomeTeam := NormalizeTeamName(schedule.HomeTeam)
 			normAwayTeam := NormalizeTeamName(schedule.AwayTeam)
 
+			// A team with a BYE has no opponent
+			if (normTeam == normHomeTeam && isBye(schedule.AwayTeam)) ||
+				(normTeam == normAwayTeam && isBye(schedule.HomeTeam)) {
+				return ByeTeam
+			}
+
 			if normTeam == normHomeTeam {
+				if OpponentSubMatches && schedule.AwaySubMatch != "" {
+					return schedule.AwaySubMatch
+				}
 				return schedule.AwayTeam
 			} else if normTeam == normAwayTeam {
+				if OpponentSubMatches && schedule.HomeSubMatch != "" {
+					return schedule.HomeSubMatch
+				}
 				return schedule.HomeTeam
 			}
 		}
@@ -169,56 +303,48 @@ func FindOpponent(team string, week int, schedules []models.MatchSchedule) strin
 	return "Unknown"
 }
 
-// NormalizeTeamName standardizes team names for comparison
+// TeamLetterSuffixes keeps a trailing single letter as part of the team name,
+// so teams sharing a venue ("HARBOR HILLS A", "HARBOR HILLS B") stay distinct
+var TeamLetterSuffixes = true
+
+// NormalizeTeamName standardizes team names for comparison using the aliases
+// set with SetTeamAliases, or the bundled defaults
 func NormalizeTeamName(name string) string {
-	// First, preserve original name for specific case handling
-	originalName := strings.ToUpper(name)
-
-	// Special handling for Bridge Inn teams - must be checked first
-	if strings.Contains(originalName, "BRIDGE INN 1") ||
-		(strings.Contains(originalName, "BRIDGE INN") && strings.Contains(originalName, "1")) {
-		return "BRIDGE INN 1" // Return with spaces preserved
-	} else if strings.Contains(originalName, "BRIDGE INN 2") ||
-		(strings.Contains(originalName, "BRIDGE INN") && strings.Contains(originalName, "2")) {
-		return "BRIDGE INN 2" // Return with spaces preserved
-	}
-
-	// Special handling for Sir James Pub teams
-	if strings.Contains(originalName, "SIR JAMES PUB 1") ||
-		(strings.Contains(originalName, "SIR JAMES PUB") && strings.Contains(originalName, "1") && !strings.Contains(originalName, "DOS")) {
-		return "SIR JAMES PUB 1"
-	} else if strings.Contains(originalName, "SIR JAMES PUB 2") ||
-		(strings.Contains(originalName, "SIR JAMES PUB") && (strings.Contains(originalName, "2") || strings.Contains(originalName, "DOS")) && !strings.Contains(originalName, "3")) {
-		return "SIR JAMES PUB 2"
-	} else if strings.Contains(originalName, "SIR JAMES PUB 3") ||
-		(strings.Contains(originalName, "SIR JAMES PUB") && strings.Contains(originalName, "3")) {
-		return "SIR JAMES PUB 3"
-	}
-
-	// Remove spaces, convert to uppercase, and remove non-alphanumeric chars
-	name = strings.ToUpper(name)
-	name = regexp.MustCompile(`[^A-Z0-9]`).ReplaceAllString(name, "")
-
-	// Replace common abbreviations/alternatives
-	replacements := map[string]string{
-		"THEHUTCH":       "THE HUTCH",
-		"HARBORHILLSTOO": "HARBOR HILLS TOO",
-		"HARBORHILLS2":   "HARBOR HILLS TOO",
-		"HARBORHILLSTWO": "HARBOR HILLS TOO",
-		"HILLSHASEYES":   "HILLS HAS EYES",
-		"EYESOFTHEHILL":  "HILLS HAS EYES",
-		"SIRJAMESPUBDOS": "SIR JAMES PUB 2",
-		"SIRJAMESPUB":    "SIR JAMES PUB",
-		"SPEARSNBEERS":   "SPEARS N BEERS",
-	}
-
-	for k, v := range replacements {
-		if strings.Contains(name, k) {
-			return v
-		}
+	return normalizeTeamName(name, teamAliases, teamAliasKeys)
+}
+
+// splitLetterSuffix splits a name like "HARBOR HILLS B" into its base name and trailing letter
+func splitLetterSuffix(name string) (string, string, bool) {
+	fields := strings.Fields(name)
+	if len(fields) < 2 {
+		return "", "", false
 	}
 
-	return originalName
+	last := fields[len(fields)-1]
+	if len(last) != 1 || last[0] < 'A' || last[0] > 'Z' {
+		return "", "", false
+	}
+	return strings.Join(fields[:len(fields)-1], " "), last, true
+}
+
+// colspanTeamHeader returns the team name from a row made of a single
+// cell with a colspan, which is how the pages mark team headers
+func colspanTeamHeader(cells *goquery.Selection) (string, bool) {
+	if cells.Length() != 1 {
+		return "", false
+	}
+	span, ok := cells.Attr("colspan")
+	if !ok {
+		return "", false
+	}
+	if n, err := strconv.Atoi(strings.TrimSpace(span)); err != nil || n < 2 {
+		return "", false
+	}
+	teamText := extractTeamName(normalizeCellText(cells.Text()))
+	if teamText == "" {
+		return "", false
+	}
+	return teamText, true
 }
 
 // isTeamNameLine checks if a line contains just a team name (usually all caps with no stats)
@@ -299,87 +425,75 @@ func extractTeamName(line string) string {
 }
 
 // parsePlayerStatsLine parses a line of text into player stats
-func parsePlayerStatsLine(line string) models.PlayerStat {
+func parsePlayerStatsLine(line string, config ParserConfig) (models.PlayerStat, []CellDiagnostic) {
 	var playerStat models.PlayerStat
+	var diagnostics []CellDiagnostic
 
 	// Split the line into fields (accounting for variable whitespace)
 	fields := regexp.MustCompile(`\s+`).Split(line, -1)
 
-	// Need at least 7 fields for valid player data
-	if len(fields) < 7 {
-		return playerStat
+	// Need enough fields for valid player data
+	if len(fields) < config.minRowCells() {
+		return playerStat, nil
 	}
 
 	// Determine which fields are which
 	// This is somewhat heuristic as the data format can vary
 
-	// First field usually contains the player name
-	playerStat.PlayerName = fields[0]
+	// Some rosters put a jersey/seed number before the name, as in
+	// "12 JOHN SMITH AA 10 7 25.3"
+	nameStart := 0
+	if seed, ok := leadingSeedNumber(fields); ok {
+		playerStat.SeedNumber = seed
+		nameStart = 1
+	}
 
-	// Look for second field that might be a rating like "AA", "A", "B" etc.
-	ratingIndex := -1
-	for i := 1; i < len(fields); i++ {
-		if isPlayerRating(fields[i]) {
-			ratingIndex = i
-			playerStat.SancPd = fields[i]
+	// The values start at the first numeric field, or W-L record, after the name
+	valueStart := -1
+	for i := nameStart; i < len(fields); i++ {
+		if _, _, isRecord := parseRecord(fields[i]); isNumeric(fields[i]) || isRecord {
+			valueStart = i
 			break
 		}
 	}
+	if valueStart <= nameStart {
+		return playerStat, nil
+	}
 
-	if ratingIndex == -1 {
-		// If no rating field found, assume standard layout
-		if len(fields) > 1 {
-			playerStat.SancPd = fields[1]
+	// A rating on the ladder ("AA", "A", "BB", ...) may sit between the name and
+	// the values; every field before it is part of the name, so "MARY JO ANNE"
+	// stays whole and a short last name like "JO" isn't taken for a rating
+	nameEnd := valueStart
+	if valueStart > nameStart+1 {
+		if rating, ok := models.ParseRating(fields[valueStart-1]); ok {
+			nameEnd = valueStart - 1
+			playerStat.SancPd = fields[nameEnd]
+			playerStat.Rating = rating
 		}
+	}
+	playerStat.PlayerName = strings.Join(fields[nameStart:nameEnd], " ")
 
-		// Try to parse numeric fields
-		if len(fields) > 2 {
-			playerStat.GamesPlayed, _ = strconv.Atoi(fields[2])
-		}
-		if len(fields) > 3 {
-			playerStat.GamesWon, _ = strconv.Atoi(fields[3])
-		}
-		if len(fields) > 4 {
-			playerStat.PPD, _ = strconv.ParseFloat(fields[4], 64)
-		}
-		if len(fields) > 5 {
-			playerStat.MPR, _ = strconv.ParseFloat(fields[5], 64)
-		}
-		if len(fields) > 6 {
-			playerStat.HatTricks, _ = strconv.Atoi(fields[6])
-		}
-		if len(fields) > 7 {
-			playerStat.HighScore, _ = strconv.Atoi(fields[7])
-		}
-		if len(fields) > 8 {
-			playerStat.HighCheckout, _ = strconv.Atoi(fields[8])
-		}
-	} else {
-		// If we found the rating field, parse from there
-		if ratingIndex+1 < len(fields) {
-			playerStat.GamesPlayed, _ = strconv.Atoi(fields[ratingIndex+1])
-		}
-		if ratingIndex+2 < len(fields) {
-			playerStat.GamesWon, _ = strconv.Atoi(fields[ratingIndex+2])
-		}
-		if ratingIndex+3 < len(fields) {
-			playerStat.PPD, _ = strconv.ParseFloat(fields[ratingIndex+3], 64)
-		}
-		if ratingIndex+4 < len(fields) {
-			playerStat.MPR, _ = strconv.ParseFloat(fields[ratingIndex+4], 64)
-		}
-		if ratingIndex+5 < len(fields) {
-			playerStat.HatTricks, _ = strconv.Atoi(fields[ratingIndex+5])
-		}
-		if ratingIndex+6 < len(fields) {
-			playerStat.HighScore, _ = strconv.Atoi(fields[ratingIndex+6])
-		}
-		if ratingIndex+7 < len(fields) {
-			playerStat.HighCheckout, _ = strconv.Atoi(fields[ratingIndex+7])
+	// Parse the numeric fields according to the column layout
+	for i, column := range config.valueColumns() {
+		if valueStart+i < len(fields) {
+			assignCell(&playerStat, column, fields[valueStart+i], config, &diagnostics)
 		}
 	}
 
-	return playerStat
+	return playerStat, diagnostics
+}
+
+// leadingSeedNumber returns the jersey/seed number at the start of a row: a
+// whole number directly followed by a name field
+func leadingSeedNumber(fields []string) (int, bool) {
+	if len(fields) < 2 || isNumeric(fields[1]) {
+		return 0, false
+	}
+	seed, err := strconv.Atoi(fields[0])
+	if err != nil || seed < 0 {
+		return 0, false
+	}
+	return seed, true
 }
 
 // isNumeric checks if a string contains only numeric data
@@ -389,26 +503,17 @@ func isNumeric(s string) bool {
 	return err == nil
 }
 
-// isPlayerRating checks if a string looks like a player rating (AA, A, BB, B, etc.)
-func isPlayerRating(s string) bool {
-	// Player ratings are usually 1-2 characters from A-Z
-	if len(s) > 3 {
-		return false
-	}
-
-	// Must be all uppercase A-Z
-	for _, c := range s {
-		if c < 'A' || c > 'Z' {
-			return false
-		}
-	}
-
-	// Common patterns: A, AA, B, BB, etc.
-	return true
+// normalizeCellText cleans up the text of a table cell: non-breaking spaces
+// (which strings.Fields treats as spaces) and runs of whitespace become single
+// spaces, zero-width spaces are dropped, and the result is trimmed. goquery has
+// already decoded HTML entities like &amp;.
+func normalizeCellText(s string) string {
+	s = strings.ReplaceAll(s, "\u200b", "")
+	return strings.Join(strings.Fields(s), " ")
 }
 
 // parseTeamTotalsLine parses a team totals line into team stats
-func parseTeamTotalsLine(line string) models.TeamStat {
+func parseTeamTotalsLine(line string, config ParserConfig) models.TeamStat {
 	var teamStat models.TeamStat
 
 	// Check if this is actually a team totals line
@@ -446,91 +551,195 @@ func parseTeamTotalsLine(line string) models.TeamStat {
 	teamStat.TeamName = "TEAM" // Will be replaced with actual team name later
 	teamStat.GamesPlayed, _ = strconv.Atoi(dataFields[0])
 	teamStat.GamesWon, _ = strconv.Atoi(dataFields[1])
-	teamStat.PPD, _ = strconv.ParseFloat(dataFields[2], 64)
-	teamStat.MPR, _ = strconv.ParseFloat(dataFields[3], 64)
+	teamStat.PPD, _ = strconv.ParseFloat(normalizeDecimal(dataFields[2], config.Decimal), 64)
+	teamStat.MPR, _ = strconv.ParseFloat(normalizeDecimal(dataFields[3], config.Decimal), 64)
 
 	return teamStat
 }
 
-// Helper function to sanitize numeric strings by removing non-numeric characters except decimal points
+// numberPattern matches a sanitized number: an optional leading minus, digits with
+// an optional decimal point, and an optional exponent
+var numberPattern = regexp.MustCompile(`^-?(\d+\.?\d*|\.\d+)([eE][-+]?\d+)?$`)
+
+// sanitizeNumberString strips decorations such as thousands separators, footnote
+// markers and a leading plus sign from a numeric cell, keeping a single leading
+// minus sign. It returns an empty string when what's left isn't a valid number,
+// rather than gluing the remaining digits together.
 func sanitizeNumberString(s string) string {
 	s = strings.TrimSpace(s)
-	result := ""
-	for _, c := range s {
-		if (c >= '0' && c <= '9') || c == '.' {
-			result += string(c)
+	s = strings.Replace(s, "\u2212", "-", 1)
+	s = strings.NewReplacer(",", "", "*", "", "%", "", " ", "").Replace(s)
+	s = strings.TrimPrefix(s, "+")
+	if !numberPattern.MatchString(s) {
+		return ""
+	}
+	return s
+}
+
+// parseIntCell parses an integer cell, accepting scientific notation such as "1e3".
+// It returns false when the cell holds something other than a number.
+func parseIntCell(raw string) (int, bool) {
+	s := sanitizeNumberString(raw)
+	if n, err := strconv.Atoi(s); err == nil {
+		return n, true
+	}
+	if strings.ContainsAny(s, "eE") {
+		if f, err := strconv.ParseFloat(s, 64); err == nil {
+			return int(f), true
 		}
 	}
-	return result
+	return 0, false
+}
+
+// parseFloatCell parses a decimal cell such as PPD or MPR, returning false when
+// the cell holds something other than a number
+func parseFloatCell(raw string, mode DecimalMode) (float64, bool) {
+	f, err := strconv.ParseFloat(sanitizeNumberString(normalizeDecimal(raw, mode)), 64)
+	return f, err == nil
+}
+
+// assignColumn parses a raw cell value into the PlayerStat field for a column.
+// It returns false when a non-blank cell couldn't be parsed; the field is then zero.
+func assignColumn(playerStat *models.PlayerStat, column Column, raw string, config ParserConfig) bool {
+	if strings.TrimSpace(raw) == "" {
+		return true
+	}
+
+	ok := true
+	switch column {
+	case ColumnPlayer:
+		playerStat.PlayerName = raw
+	case ColumnSancPd:
+		playerStat.SancPd = raw
+		playerStat.Rating, _ = models.ParseRating(raw)
+	case ColumnGames:
+		playerStat.GamesPlayed, ok = parseIntCell(raw)
+	case ColumnWins:
+		playerStat.GamesWon, ok = parseIntCell(raw)
+	case ColumnPPD:
+		playerStat.PPD, ok = parseFloatCell(raw, config.Decimal)
+	case ColumnMPR:
+		playerStat.MPR, ok = parseFloatCell(raw, config.Decimal)
+	case ColumnHatTricks:
+		playerStat.HatTricks, ok = parseIntCell(raw)
+	case ColumnHighScore:
+		playerStat.HighScore, ok = parseIntCell(raw)
+	case ColumnHighCheckout:
+		playerStat.HighCheckout, ok = parseIntCell(raw)
+	case ColumnPlusMinus:
+		playerStat.PlusMinus, ok = parseIntCell(raw)
+	case ColumnDartsThrown:
+		playerStat.DartsThrown, ok = parseIntCell(raw)
+	case ColumnRecord:
+		var wins, losses int
+		if wins, losses, ok = parseRecord(raw); ok {
+			playerStat.GamesWon = wins
+			playerStat.GamesPlayed = wins + losses
+		}
+	}
+	return ok
+}
+
+// parseRecord splits a "W-L" record such as "12-4" into wins and losses
+func parseRecord(s string) (int, int, bool) {
+	match := regexp.MustCompile(`^\s*(\d+)\s*[-–/]\s*(\d+)\s*$`).FindStringSubmatch(s)
+	if match == nil {
+		return 0, 0, false
+	}
+
+	wins, err := strconv.Atoi(match[1])
+	if err != nil {
+		return 0, 0, false
+	}
+	losses, err := strconv.Atoi(match[2])
+	if err != nil {
+		return 0, 0, false
+	}
+	return wins, losses, true
 }
 
 // ExtractPlayerStats extracts player statistics from the HTML content
 func ExtractPlayerStats(htmlContent string) ([]models.PlayerStat, []models.TeamStat) {
-	var playerStats []models.PlayerStat
-	var teamStats []models.TeamStat
-	var teamName string
+	return ExtractPlayerStatsWithConfig(htmlContent, DefaultParserConfig())
+}
 
-	log.Println("Extracting player stats from HTML...")
+// ExtractPlayerStatsWithConfig extracts player statistics from the HTML content using the given configuration
+func ExtractPlayerStatsWithConfig(htmlContent string, config ParserConfig) ([]models.PlayerStat, []models.TeamStat) {
+	result := ParsePlayerStats(htmlContent, config)
+	return result.PlayerStats, result.TeamStats
+}
 
-	// Look for the Combined X01/Cricket games section
-	startMarker := "Combined X01/Cricket games, sorted by Team + PPD:"
-	endMarker := "Most Improved Players for week"
+// ParseWarning notes a stats cell that couldn't be parsed: the player, the
+// column and the raw value that was read as zero
+type ParseWarning = CellDiagnostic
 
-	startIndex := strings.Index(htmlContent, startMarker)
-	if startIndex == -1 {
-		// Try alternative markers if the exact one is not found
-		alternatePossibleMarkers := []string{
-			"All X01 games, sorted by PPD:",
-			"X01/Cricket games, sorted by Team",
-			"Combined X01/Cricket games",
-			"X01 games, sorted by PPD",
-		}
+// ExtractPlayerStatsVerbose extracts player statistics like ExtractPlayerStats,
+// also returning a warning for each cell that couldn't be parsed
+func ExtractPlayerStatsVerbose(htmlContent string) ([]models.PlayerStat, []models.TeamStat, []ParseWarning) {
+	result := ParsePlayerStats(htmlContent, DefaultParserConfig())
+	return result.PlayerStats, result.TeamStats, result.Diagnostics
+}
 
-		for _, marker := range alternatePossibleMarkers {
-			startIndex = strings.Index(htmlContent, marker)
-			if startIndex != -1 {
-				log.Printf("Using alternative start marker: '%s'", marker)
-				break
-			}
-		}
+// ExtractPlayerStatsWithMarkers extracts player statistics from the section of
+// the HTML content delimited by markers, e.g. X01SectionMarkers
+func ExtractPlayerStatsWithMarkers(htmlContent string, markers SectionMarkers) ([]models.PlayerStat, []models.TeamStat) {
+	config := DefaultParserConfig()
+	config.Markers = markers
+	return ExtractPlayerStatsWithConfig(htmlContent, config)
+}
 
-		if startIndex == -1 {
-			log.Printf("No suitable start marker found in HTML")
-			return playerStats, teamStats
-		}
-	}
+// ExtractCricketStats extracts player statistics from the Cricket section,
+// sorted by MPR. Only the Cricket stats are filled in, so PPD is left zero.
+func ExtractCricketStats(htmlContent string) []models.PlayerStat {
+	config := DefaultParserConfig()
+	config.Columns = CricketColumns
+	config.Markers = CricketSectionMarkers
+	playerStats, _ := ExtractPlayerStatsWithConfig(htmlContent, config)
+	return playerStats
+}
 
-	endIndex := strings.Index(htmlContent[startIndex:], endMarker)
-	if endIndex == -1 {
-		// If end marker not found, try to go to the end of the document
-		endIndex = len(htmlContent) - startIndex
-		log.Printf("End marker not found, using rest of document (%d bytes)", endIndex)
-	} else {
-		endIndex += startIndex // Adjust for the substring offset
-	}
+// ParsePlayerStats extracts player statistics like ExtractPlayerStatsWithConfig,
+// also reporting the cells that couldn't be parsed
+func ParsePlayerStats(htmlContent string, config ParserConfig) ParseResult {
+	var playerStats []models.PlayerStat
+	var teamStats []models.TeamStat
+	var diagnostics []CellDiagnostic
+	var teamName string
+
+	log.Println("Extracting player stats from HTML...")
 
-	// Extract the section between markers
-	sectionHTML := htmlContent[startIndex:endIndex]
+	// Isolate the player stats section
+	sectionHTML, err := ExtractStatsSection(htmlContent, config)
+	if err != nil {
+		log.Printf("Error finding player stats section: %v", err)
+		return ParseResult{}
+	}
 	log.Printf("Found player stats section (length: %d characters)", len(sectionHTML))
 
 	// Parse the HTML section with goquery
 	doc, err := goquery.NewDocumentFromReader(strings.NewReader(sectionHTML))
 	if err != nil {
 		log.Printf("Error parsing player stats section: %v", err)
-		return playerStats, teamStats
+		return ParseResult{}
 	}
 
 	// Try direct extraction from table structures first
-	playerStats = extractPlayerStatsFromTable(doc, teamName)
+	if config.SplitTables {
+		playerStats, diagnostics = extractPlayerStatsFromSplitTables(doc, teamName, config)
+	} else {
+		playerStats, diagnostics = extractPlayerStatsFromTable(doc, teamName, config)
+	}
 
 	// If no players found, try line-by-line parsing
 	if len(playerStats) == 0 {
 		log.Println("Table extraction found no players, trying line-by-line parsing...")
 
 		// Process the HTML to extract player stats
+		var layout fixedWidthLayout
+		lineConfig := config
 		lines := strings.Split(sectionHTML, "\n")
-		for _, line := range lines {
-			line = strings.TrimSpace(line)
+		for _, rawLine := range lines {
+			line := strings.TrimSpace(rawLine)
 
 			// If line contains a team name (usually in all caps with no other data)
 			if isTeamNameLine(line) {
@@ -539,24 +748,41 @@ func ExtractPlayerStats(htmlContent string) ([]models.PlayerStat, []models.TeamS
 				continue
 			}
 
+			// Remember the column positions of fixed-width headers
+			if strings.Contains(line, "Player") {
+				lineConfig = config.withHeaders(strings.Fields(line))
+				layout = newFixedWidthLayout(rawLine, len(lineConfig.Columns))
+			}
+
 			// Skip empty lines and header lines
 			if line == "" || strings.Contains(line, "Player") ||
 				strings.Contains(line, "-----") || strings.Contains(line, "Team Totals:") {
 				continue
 			}
 
-			// Try to parse a player stat line
-			playerStat := parsePlayerStatsLine(line)
+			// Try to parse a player stat line, using the header's column positions
+			// when blank cells leave fewer values than columns
+			var playerStat models.PlayerStat
+			var lineDiagnostics []CellDiagnostic
+			if layout != nil && len(strings.Fields(line)) < len(layout) {
+				playerStat, lineDiagnostics = parseFixedWidthLine(rawLine, layout, lineConfig)
+			} else {
+				playerStat, lineDiagnostics = parsePlayerStatsLine(line, lineConfig)
+			}
 			if playerStat.PlayerName != "" {
 				playerStat.Team = teamName
 				playerStats = append(playerStats, playerStat)
+				for _, diagnostic := range lineDiagnostics {
+					diagnostic.Team = teamName
+					diagnostics = append(diagnostics, diagnostic)
+				}
 				log.Printf("Added player: %s (Team: %s, PPD: %.2f)",
 					playerStat.PlayerName, playerStat.Team, playerStat.PPD)
 			}
 
 			// Check for team totals line
 			if strings.Contains(line, "Team Totals:") {
-				teamStat := parseTeamTotalsLine(line)
+				teamStat := parseTeamTotalsLine(line, config)
 				if teamStat.TeamName != "" {
 					teamStat.TeamName = teamName
 					teamStats = append(teamStats, teamStat)
@@ -567,21 +793,113 @@ func ExtractPlayerStats(htmlContent string) ([]models.PlayerStat, []models.TeamS
 	}
 
 	// Post-processing to correct team assignments for specific players
-	for i := range playerStats {
-		// Special case for Steve Wheelock - always assign to Bridge Inn 2
-		if strings.ToUpper(playerStats[i].PlayerName) == "STEVE WHEELOCK" {
-			playerStats[i].Team = "BRIDGE INN 2"
-			log.Printf("Reassigned %s to team: %s", playerStats[i].PlayerName, playerStats[i].Team)
-		}
+	applyPlayerTeamOverrides(playerStats)
+
+	// Merge players listed more than once, e.g. in the combined section and a subsection
+	if !config.KeepDuplicates {
+		playerStats = DedupePlayerStats(playerStats)
+	}
+
+	// Derive team totals from the players when the page has none
+	if len(teamStats) == 0 && len(playerStats) > 0 {
+		teamStats = ComputeTeamStatsFromPlayers(playerStats)
+		log.Printf("No team totals found, computed %d from player stats", len(teamStats))
+	}
+
+	for _, diagnostic := range diagnostics {
+		log.Printf("Warning: %s", diagnostic)
 	}
 
 	log.Printf("Extracted %d player stats and %d team stats", len(playerStats), len(teamStats))
-	return playerStats, teamStats
+	return ParseResult{PlayerStats: playerStats, TeamStats: teamStats, Diagnostics: diagnostics}
+}
+
+// ExtractWeekAndDate finds the week number and date stated in the body of a
+// standings page, e.g. "Week 5 - October 6, 2024". It returns 0 and an empty
+// date when the page doesn't state them.
+func ExtractWeekAndDate(htmlContent string) (int, string) {
+	text := htmlContent
+	if doc, err := goquery.NewDocumentFromReader(strings.NewReader(htmlContent)); err == nil {
+		text = doc.Text()
+	}
+
+	weekDateRegex := regexp.MustCompile(`(?i)Week\s*(\d+)\s*[-:,]?\s*([A-Za-z]+\.?\s+\d{1,2}\s*,?\s*\d{4})?`)
+	matches := weekDateRegex.FindAllStringSubmatch(text, -1)
+	if len(matches) == 0 {
+		return 0, ""
+	}
+
+	// Prefer the first mention that carries a date, such as the page heading
+	match := matches[0]
+	for _, m := range matches {
+		if m[2] != "" {
+			match = m
+			break
+		}
+	}
+
+	week, err := strconv.Atoi(match[1])
+	if err != nil {
+		return 0, ""
+	}
+	return week, strings.Join(strings.Fields(match[2]), " ")
+}
+
+// ExtractSegment returns the season segment a standings page states, "first"
+// or "second" for pages headed e.g. "Second Half Standings", or an empty string
+func ExtractSegment(htmlContent string) string {
+	match := regexp.MustCompile(`(?i)\b(first|second|1st|2nd)\s+half\b`).FindStringSubmatch(htmlContent)
+	if match == nil {
+		return ""
+	}
+
+	switch strings.ToLower(match[1]) {
+	case "first", "1st":
+		return "first"
+	default:
+		return "second"
+	}
+}
+
+// ExtractStatsSection returns the part of the HTML content between the
+// configured start and end markers, without parsing it
+func ExtractStatsSection(htmlContent string, config ParserConfig) (string, error) {
+	startIndex := -1
+	for i, marker := range config.Markers.Start {
+		startIndex = strings.Index(htmlContent, marker)
+		if startIndex != -1 {
+			if i > 0 {
+				log.Printf("Using alternative start marker: '%s'", marker)
+			}
+			break
+		}
+	}
+
+	if startIndex == -1 {
+		return "", ErrSectionNotFound
+	}
+
+	endIndex := -1
+	for _, marker := range config.Markers.End {
+		endIndex = strings.Index(htmlContent[startIndex:], marker)
+		if endIndex != -1 {
+			break
+		}
+	}
+
+	if endIndex == -1 {
+		// If end marker not found, try to go to the end of the document
+		endIndex = len(htmlContent) - startIndex
+		log.Printf("End marker not found, using rest of document (%d bytes)", endIndex)
+	}
+
+	return htmlContent[startIndex : startIndex+endIndex], nil
 }
 
 // extractPlayerStatsFromTable attempts to extract player stats from tables in the document
-func extractPlayerStatsFromTable(doc *goquery.Document, defaultTeam string) []models.PlayerStat {
+func extractPlayerStatsFromTable(doc *goquery.Document, defaultTeam string, config ParserConfig) ([]models.PlayerStat, []CellDiagnostic) {
 	var playerStats []models.PlayerStat
+	var diagnostics []CellDiagnostic
 
 	// Find all tables in the document
 	doc.Find("table").Each(func(i int, table *goquery.Selection) {
@@ -590,22 +908,21 @@ func extractPlayerStatsFromTable(doc *goquery.Document, defaultTeam string) []mo
 		// Check if this table has player stats headers
 		headers := []string{}
 		table.Find("tr:first-child td, tr:first-child th").Each(func(j int, cell *goquery.Selection) {
-			headerText := strings.TrimSpace(cell.Text())
+			headerText := normalizeCellText(cell.Text())
 			headers = append(headers, headerText)
 		})
 
 		// Check if headers match player stats structure
 		hasPlayerColumn := false
-		hasPPDColumn := false
+		hasStatsColumn := false
 		teamNameFromHeader := ""
 
 		for _, header := range headers {
 			if strings.Contains(header, "Player") {
 				hasPlayerColumn = true
 			}
-			if strings.Contains(header, "PPD") {
-				hasPPDColumn = true
-				// Check if the header contains a team name
+			if strings.Contains(header, config.statsHeader()) {
+				hasStatsColumn = true
 			}
 			if strings.Contains(header, "BRIDGE INN") {
 				if strings.Contains(header, "1") {
@@ -618,19 +935,27 @@ func extractPlayerStatsFromTable(doc *goquery.Document, defaultTeam string) []mo
 			}
 		}
 
-		if !hasPlayerColumn || !hasPPDColumn {
+		if !hasPlayerColumn || !hasStatsColumn {
 			log.Printf("Table #%d doesn't appear to be a player stats table", i)
 			return
 		}
 
 		log.Printf("Found potential player stats table #%d with headers: %v", i, headers)
 
+		// Follow the header order, picking up optional columns such as plus/minus
+		tableConfig := config.withHeaders(headers)
+		nameIdx := tableConfig.playerColumnIndex()
+
 		// Extract player rows
 		var currentTeam string = defaultTeam
-		// If we found a team name in the header, use it as the initial team name
+		// If we found a team name in the header, use it as the initial team name,
+		// otherwise look for one in the table's caption or the heading before it
 		if teamNameFromHeader != "" {
 			currentTeam = teamNameFromHeader
 			log.Printf("Using team name from header: %s", currentTeam)
+		} else if captionTeam := tableCaptionTeam(table); captionTeam != "" {
+			currentTeam = captionTeam
+			log.Printf("Using team name from caption: %s", currentTeam)
 		}
 
 		table.Find("tr").Each(func(rowIdx int, row *goquery.Selection) {
@@ -641,9 +966,16 @@ func extractPlayerStatsFromTable(doc *goquery.Document, defaultTeam string) []mo
 
 			cells := row.Find("td")
 
-			// Check if this is a team header row (usually has fewer cells)
+			// A single cell spanning the row is a team header
+			if teamText, ok := colspanTeamHeader(cells); ok {
+				currentTeam = teamText
+				log.Printf("Found colspan team header: %s", currentTeam)
+				return
+			}
+
+			// Otherwise check for a team header row by its look (usually has fewer cells)
 			if cells.Length() <= 3 {
-				teamText := strings.TrimSpace(row.Text())
+				teamText := normalizeCellText(row.Text())
 				if isTeamNameLine(teamText) {
 					currentTeam = teamText
 					log.Printf("Found team name row: %s", currentTeam)
@@ -651,8 +983,8 @@ func extractPlayerStatsFromTable(doc *goquery.Document, defaultTeam string) []mo
 				}
 			}
 
-			// Must have at least 7 cells for a valid player row
-			if cells.Length() < 7 {
+			// Must have enough cells for a valid player row
+			if cells.Length() < tableConfig.minRowCells() {
 				return
 			}
 
@@ -660,56 +992,39 @@ func extractPlayerStatsFromTable(doc *goquery.Document, defaultTeam string) []mo
 			cellTexts := []string{}
 			cells.Each(func(cellIdx int, cell *goquery.Selection) {
 				// Get all text from cell and its children
-				cellText := strings.TrimSpace(cell.Text())
+				cellText := normalizeCellText(cell.Text())
 				cellTexts = append(cellTexts, cellText)
 			})
 
-			// Must have content in first cell (player name)
-			if len(cellTexts) == 0 || cellTexts[0] == "" ||
-				cellTexts[0] == "Player" || strings.Contains(cellTexts[0], "Team Totals") {
+			// Must have content in the player name cell
+			if len(cellTexts) <= nameIdx || cellTexts[nameIdx] == "" ||
+				cellTexts[nameIdx] == "Player" || strings.Contains(cellTexts[nameIdx], "Team Totals") {
 				return
 			}
 
 			// Skip header rows
-			if strings.Contains(strings.ToLower(cellTexts[0]), "player") {
+			if strings.Contains(strings.ToLower(cellTexts[nameIdx]), "player") {
 				return
 			}
 
 			// Create player stat object
 			playerStat := models.PlayerStat{
-				PlayerName: cellTexts[0],
+				PlayerName: cellTexts[nameIdx],
 				Team:       currentTeam,
 			}
 
-			// Parse remaining fields
-			if len(cellTexts) > 1 {
-				playerStat.SancPd = cellTexts[1]
-			}
-			if len(cellTexts) > 2 {
-				playerStat.GamesPlayed, _ = strconv.Atoi(sanitizeNumberString(cellTexts[2]))
-			}
-			if len(cellTexts) > 3 {
-				playerStat.GamesWon, _ = strconv.Atoi(sanitizeNumberString(cellTexts[3]))
-			}
-			if len(cellTexts) > 4 {
-				playerStat.PPD, _ = strconv.ParseFloat(sanitizeNumberString(cellTexts[4]), 64)
-			}
-			if len(cellTexts) > 5 {
-				playerStat.MPR, _ = strconv.ParseFloat(sanitizeNumberString(cellTexts[5]), 64)
-			}
-			if len(cellTexts) > 6 {
-				playerStat.HatTricks, _ = strconv.Atoi(sanitizeNumberString(cellTexts[6]))
-			}
-			if len(cellTexts) > 7 {
-				playerStat.HighScore, _ = strconv.Atoi(sanitizeNumberString(cellTexts[7]))
-			}
-			if len(cellTexts) > 8 {
-				playerStat.HighCheckout, _ = strconv.Atoi(sanitizeNumberString(cellTexts[8]))
+			// Parse the fields according to the column layout
+			var rowDiagnostics []CellDiagnostic
+			for cellIdx, column := range tableConfig.Columns {
+				if cellIdx < len(cellTexts) {
+					assignCell(&playerStat, column, cellTexts[cellIdx], tableConfig, &rowDiagnostics)
+				}
 			}
 
 			// Only add valid player data
 			if playerStat.PlayerName != "" && playerStat.PlayerName != "Combined" {
 				playerStats = append(playerStats, playerStat)
+				diagnostics = append(diagnostics, rowDiagnostics...)
 				log.Printf("Added player from table: %s (Team: %s, Games: %d, PPD: %.2f)",
 					playerStat.PlayerName, playerStat.Team, playerStat.GamesPlayed, playerStat.PPD)
 			}
@@ -723,7 +1038,7 @@ func extractPlayerStatsFromTable(doc *goquery.Document, defaultTeam string) []mo
 		// Find rows that look like player data
 		doc.Find("tr").Each(func(i int, row *goquery.Selection) {
 			// Get all text in the row
-			rowText := strings.TrimSpace(row.Text())
+			rowText := normalizeCellText(row.Text())
 
 			// Skip irrelevant rows
 			if rowText == "" ||
@@ -741,39 +1056,21 @@ func extractPlayerStatsFromTable(doc *goquery.Document, defaultTeam string) []mo
 				// Extract all cell contents
 				var cellTexts []string
 				row.Find("td").Each(func(j int, cell *goquery.Selection) {
-					cellText := strings.TrimSpace(cell.Text())
+					cellText := normalizeCellText(cell.Text())
 					cellTexts = append(cellTexts, cellText)
 				})
 
-				if len(cellTexts) >= 7 {
+				if len(cellTexts) >= config.minRowCells() {
 					playerStat := models.PlayerStat{
 						PlayerName: cellTexts[0],
 						Team:       defaultTeam,
 					}
 
-					if len(cellTexts) > 1 {
-						playerStat.SancPd = cellTexts[1]
-					}
-					if len(cellTexts) > 2 {
-						playerStat.GamesPlayed, _ = strconv.Atoi(sanitizeNumberString(cellTexts[2]))
-					}
-					if len(cellTexts) > 3 {
-						playerStat.GamesWon, _ = strconv.Atoi(sanitizeNumberString(cellTexts[3]))
-					}
-					if len(cellTexts) > 4 {
-						playerStat.PPD, _ = strconv.ParseFloat(sanitizeNumberString(cellTexts[4]), 64)
-					}
-					if len(cellTexts) > 5 {
-						playerStat.MPR, _ = strconv.ParseFloat(sanitizeNumberString(cellTexts[5]), 64)
-					}
-					if len(cellTexts) > 6 {
-						playerStat.HatTricks, _ = strconv.Atoi(sanitizeNumberString(cellTexts[6]))
-					}
-					if len(cellTexts) > 7 {
-						playerStat.HighScore, _ = strconv.Atoi(sanitizeNumberString(cellTexts[7]))
-					}
-					if len(cellTexts) > 8 {
-						playerStat.HighCheckout, _ = strconv.Atoi(sanitizeNumberString(cellTexts[8]))
+					// Parse the fields according to the column layout
+					for cellIdx, column := range config.Columns {
+						if cellIdx < len(cellTexts) {
+							assignCell(&playerStat, column, cellTexts[cellIdx], config, &diagnostics)
+						}
 					}
 
 					playerStats = append(playerStats, playerStat)
@@ -784,7 +1081,31 @@ func extractPlayerStatsFromTable(doc *goquery.Document, defaultTeam string) []mo
 		})
 	}
 
-	return playerStats
+	return playerStats, diagnostics
+}
+
+// tableCaptionTeam returns the team name given by a table's <caption>, or by the
+// nearest heading before the table, or an empty string if neither names a team.
+// Headings before an earlier table are not considered.
+func tableCaptionTeam(table *goquery.Selection) string {
+	candidates := []string{normalizeCellText(table.Find("caption").First().Text())}
+
+	for prev := table.Prev(); prev.Length() > 0; prev = prev.Prev() {
+		if goquery.NodeName(prev) == "table" {
+			break
+		}
+		if prev.Is("h1, h2, h3, h4, h5, h6") {
+			candidates = append(candidates, normalizeCellText(prev.Text()))
+			break
+		}
+	}
+
+	for _, candidate := range candidates {
+		if isTeamNameLine(candidate) && !strings.Contains(strings.ToLower(candidate), "sorted by") {
+			return extractTeamName(candidate)
+		}
+	}
+	return ""
 }
 
 // ProcessStandingsPage processes a single standings page
@@ -796,11 +1117,19 @@ func ProcessStandingsPage(url string, week int) (*models.WeeklyStats, error) {
 	}
 
 	// Extract player and team stats
-	playerStats, teamStats := ExtractPlayerStats(htmlContent)
+	playerStats, teamStats, err := ExtractPlayerStatsSafe(htmlContent, DefaultParserConfig(), week, url)
+	if err != nil {
+		return nil, err
+	}
+
+	// Prefer the date stated on the page itself
+	_, date := ExtractWeekAndDate(htmlContent)
 
 	// Create a WeeklyStats object
 	weeklyStats := &models.WeeklyStats{
 		Week:        week,
+		Date:        date,
+		Segment:     ExtractSegment(htmlContent),
 		PlayerStats: playerStats,
 		TeamStats:   teamStats,
 	}
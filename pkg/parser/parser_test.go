@@ -0,0 +1,349 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/PuerkitoBio/goquery"
+
+	"github.com/myusername/dart-statistic-scraper/pkg/models"
+)
+
+func TestParseFloatCellDecimalSeparators(t *testing.T) {
+	tests := []struct {
+		raw  string
+		mode DecimalMode
+		want float64
+	}{
+		{"24,35", DecimalAuto, 24.35},
+		{"24.35", DecimalAuto, 24.35},
+		{"1,234.5", DecimalAuto, 1234.5},
+		{"2,81", DecimalAuto, 2.81},
+		{"24,35", DecimalComma, 24.35},
+		{"1.234,5", DecimalComma, 1234.5},
+		{"24,35", DecimalPeriod, 2435},
+	}
+
+	for _, tt := range tests {
+		got, ok := parseFloatCell(tt.raw, tt.mode)
+		if !ok || got != tt.want {
+			t.Errorf("parseFloatCell(%q, %d) = %v, %v; want %v", tt.raw, tt.mode, got, ok, tt.want)
+		}
+	}
+}
+
+func TestParsePlayerStatsLineDecimalComma(t *testing.T) {
+	got, _ := parsePlayerStatsLine("JOHN SMITH 10 7 24,35 2,81 3 140 96", DefaultParserConfig())
+	if got.PPD != 24.35 || got.MPR != 2.81 {
+		t.Errorf("PPD, MPR = %v, %v; want 24.35, 2.81", got.PPD, got.MPR)
+	}
+}
+
+func TestNormalizeTeamNameLetterSuffixes(t *testing.T) {
+	a, b := NormalizeTeamName("Harbor Hills A"), NormalizeTeamName("Harbor Hills B")
+	if a != "HARBOR HILLS A" || b != "HARBOR HILLS B" {
+		t.Errorf("NormalizeTeamName() = %q, %q; want HARBOR HILLS A, HARBOR HILLS B", a, b)
+	}
+	if got := NormalizeTeamName("Harbor Hills Two B"); got != "HARBOR HILLS TOO B" {
+		t.Errorf("NormalizeTeamName(%q) = %q, want %q", "Harbor Hills Two B", got, "HARBOR HILLS TOO B")
+	}
+
+	TeamLetterSuffixes = false
+	defer func() { TeamLetterSuffixes = true }()
+	if got := NormalizeTeamName("Sir James Pub B"); got != "SIR JAMES PUB" {
+		t.Errorf("NormalizeTeamName(%q) without letter suffixes = %q, want %q", "Sir James Pub B", got, "SIR JAMES PUB")
+	}
+}
+
+func TestParseRecord(t *testing.T) {
+	tests := []struct {
+		raw        string
+		wantOK     bool
+		wantWins   int
+		wantLosses int
+	}{
+		{"12-4", true, 12, 4},
+		{" 3 - 0 ", true, 3, 0},
+		{"7/2", true, 7, 2},
+		{"12-x", false, 0, 0},
+		{"12", false, 0, 0},
+	}
+
+	for _, tt := range tests {
+		wins, losses, ok := parseRecord(tt.raw)
+		if ok != tt.wantOK || wins != tt.wantWins || losses != tt.wantLosses {
+			t.Errorf("parseRecord(%q) = %d, %d, %v; want %d, %d, %v",
+				tt.raw, wins, losses, ok, tt.wantWins, tt.wantLosses, tt.wantOK)
+		}
+	}
+}
+
+func TestParsePlayerStatsLineRecordColumns(t *testing.T) {
+	config := DefaultParserConfig()
+	config.Columns = RecordColumns
+
+	got, _ := parsePlayerStatsLine("JOHN SMITH A 12-4 25.3 2.1 3 140 96", config)
+	if got.PlayerName != "JOHN SMITH" || got.GamesWon != 12 || got.GamesPlayed != 16 || got.PPD != 25.3 {
+		t.Errorf("parsePlayerStatsLine() = %s, %d wins of %d games, %v PPD; want JOHN SMITH, 12 of 16, 25.3",
+			got.PlayerName, got.GamesWon, got.GamesPlayed, got.PPD)
+	}
+}
+
+func TestSanitizeNumberString(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"-3", "-3"},
+		{"−3", "-3"},
+		{"+7", "7"},
+		{"1,234", "1234"},
+		{"25.3*", "25.3"},
+		{"1e3", "1e3"},
+		{"-2.5E-1", "-2.5E-1"},
+		{"", ""},
+		{"--3", ""},
+		{"3-", ""},
+		{"1.2.3", ""},
+		{"N/A", ""},
+		{"12abc", ""},
+	}
+
+	for _, tt := range tests {
+		if got := sanitizeNumberString(tt.in); got != tt.want {
+			t.Errorf("sanitizeNumberString(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestParseIntCell(t *testing.T) {
+	tests := []struct {
+		in     string
+		want   int
+		wantOK bool
+	}{
+		{"-3", -3, true},
+		{"12", 12, true},
+		{"1e3", 1000, true},
+		{"abc", 0, false},
+		{"-", 0, false},
+	}
+
+	for _, tt := range tests {
+		if got, ok := parseIntCell(tt.in); got != tt.want || ok != tt.wantOK {
+			t.Errorf("parseIntCell(%q) = %d, %v; want %d, %v", tt.in, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestExtractPlayerStatsFromTableCaptionTeams(t *testing.T) {
+	header := `<tr><th>Player</th><th>SancPd</th><th>Games</th><th>Wins</th><th>PPD</th><th>MPR</th><th>Hat</th><th>High</th><th>Out</th></tr>`
+	page := `<body>
+<table><caption>REDHEADS</caption>` + header + `
+<tr><td>JOHN SMITH</td><td>A</td><td>10</td><td>6</td><td>24.50</td><td>2.10</td><td>1</td><td>140</td><td>96</td></tr>
+</table>
+<h3>HARBOR HILLS</h3>
+<p>Week 3</p>
+<table>` + header + `
+<tr><td>MARY JONES</td><td>B</td><td>8</td><td>3</td><td>18.20</td><td>1.60</td><td>0</td><td>100</td><td>40</td></tr>
+</table>
+<table>` + header + `
+<tr><td>TOM NG</td><td>C</td><td>9</td><td>4</td><td>20.10</td><td>1.90</td><td>0</td><td>120</td><td>60</td></tr>
+</table>
+</body>`
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(page))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	playerStats, _ := extractPlayerStatsFromTable(doc, "UNKNOWN", DefaultParserConfig())
+	// The last table has no heading of its own, so it keeps the default team
+	want := map[string]string{"JOHN SMITH": "REDHEADS", "MARY JONES": "HARBOR HILLS", "TOM NG": "UNKNOWN"}
+	if len(playerStats) != len(want) {
+		t.Fatalf("extractPlayerStatsFromTable() found %d players, want %d: %+v", len(playerStats), len(want), playerStats)
+	}
+	for _, player := range playerStats {
+		if player.Team != want[player.PlayerName] {
+			t.Errorf("%s is on team %q, want %q", player.PlayerName, player.Team, want[player.PlayerName])
+		}
+	}
+}
+
+func TestApplyPlayerTeamOverrides(t *testing.T) {
+	// Nobody is moved by default
+	unchanged := []models.PlayerStat{{PlayerName: "STEVE WHEELOCK", Team: "BRIDGE INN 1"}}
+	applyPlayerTeamOverrides(unchanged)
+	if unchanged[0].Team != "BRIDGE INN 1" {
+		t.Errorf("player moved to %q without an override", unchanged[0].Team)
+	}
+
+	PlayerTeamOverrides = map[string]string{"STEVE WHEELOCK": "BRIDGE INN 2"}
+	defer func() { PlayerTeamOverrides = map[string]string{} }()
+
+	playerStats := []models.PlayerStat{
+		{PlayerName: "Steve  Wheelock", Team: "BRIDGE INN 1"},
+		{PlayerName: "JOHN SMITH", Team: "BRIDGE INN 1"},
+	}
+	applyPlayerTeamOverrides(playerStats)
+
+	if playerStats[0].Team != "BRIDGE INN 2" {
+		t.Errorf("overridden player is on %q, want BRIDGE INN 2", playerStats[0].Team)
+	}
+	if playerStats[1].Team != "BRIDGE INN 1" {
+		t.Errorf("other player moved to %q, want BRIDGE INN 1", playerStats[1].Team)
+	}
+}
+
+func TestExtractCricketStats(t *testing.T) {
+	page := `<html><body>
+<p>All X01 games, sorted by PPD:</p>
+<table>
+<tr><th>Player</th><th>SancPd</th><th>Games</th><th>Wins</th><th>PPD</th><th>MPR</th><th>Hat</th><th>High</th><th>Out</th></tr>
+<tr><td colspan="9">REDHEADS</td></tr>
+<tr><td>JOHN SMITH</td><td>A</td><td>10</td><td>6</td><td>24.50</td><td>2.10</td><td>1</td><td>140</td><td>96</td></tr>
+</table>
+<p>All Cricket games, sorted by MPR:</p>
+<table>
+<tr><th>Player</th><th>SancPd</th><th>Games</th><th>Wins</th><th>MPR</th><th>Hat</th></tr>
+<tr><td colspan="6">REDHEADS</td></tr>
+<tr><td>JOHN SMITH</td><td>A</td><td>6</td><td>4</td><td>2.85</td><td>3</td></tr>
+<tr><td>MARY JONES</td><td>B</td><td>5</td><td>1</td><td>1.40</td><td>0</td></tr>
+</table>
+<p>Most Improved Players for week</p>
+</body></html>`
+
+	playerStats := ExtractCricketStats(page)
+	want := map[string]models.PlayerStat{
+		"JOHN SMITH": {GamesPlayed: 6, GamesWon: 4, MPR: 2.85, HatTricks: 3},
+		"MARY JONES": {GamesPlayed: 5, GamesWon: 1, MPR: 1.40},
+	}
+	if len(playerStats) != len(want) {
+		t.Fatalf("ExtractCricketStats() found %d players, want %d: %+v", len(playerStats), len(want), playerStats)
+	}
+	for _, got := range playerStats {
+		w := want[got.PlayerName]
+		if got.GamesPlayed != w.GamesPlayed || got.GamesWon != w.GamesWon || got.MPR != w.MPR ||
+			got.HatTricks != w.HatTricks || got.PPD != 0 || got.Team != "REDHEADS" {
+			t.Errorf("%s = %+v, want cricket stats %+v on REDHEADS with no PPD", got.PlayerName, got, w)
+		}
+	}
+}
+
+func TestExtractPlayerStatsFromTableNbspCells(t *testing.T) {
+	page := `<table>
+<tr><th>Player</th><th>SancPd</th><th>Games</th><th>Wins</th><th>PPD</th><th>MPR</th><th>Hat</th><th>High</th><th>Out</th></tr>
+<tr><td colspan="9">&nbsp;REDHEADS&nbsp;</td></tr>
+<tr><td>&nbsp;JOHN&nbsp;&nbsp;SMITH&#8203;</td><td>A&nbsp;</td><td>&nbsp;10</td><td>6&nbsp;</td><td>&nbsp;24.50&nbsp;</td><td>2.10</td><td>1</td><td>1&nbsp;40</td><td>96</td></tr>
+<tr><td>O&#39;BRIEN &amp; SON</td><td>B</td><td>8</td><td>3</td><td>18.20</td><td>1.60</td><td>0</td><td>100</td><td>40</td></tr>
+</table>`
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(page))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	playerStats, _ := extractPlayerStatsFromTable(doc, "", DefaultParserConfig())
+	if len(playerStats) != 2 {
+		t.Fatalf("extractPlayerStatsFromTable() found %d players, want 2: %+v", len(playerStats), playerStats)
+	}
+	john := playerStats[0]
+	if john.PlayerName != "JOHN SMITH" || john.Team != "REDHEADS" || john.SancPd != "A" ||
+		john.GamesPlayed != 10 || john.GamesWon != 6 || john.PPD != 24.5 || john.HighScore != 140 {
+		t.Errorf("player from &nbsp; cells = %+v, want clean JOHN SMITH stats", john)
+	}
+	if got := playerStats[1].PlayerName; got != "O'BRIEN & SON" {
+		t.Errorf("player name with entities = %q, want %q", got, "O'BRIEN & SON")
+	}
+}
+
+func TestNormalizeCellText(t *testing.T) {
+	for in, want := range map[string]string{
+		"\u00a0JOHN\u00a0 \u00a0SMITH\u00a0 ": "JOHN SMITH",
+		"  24.50 \t\n":                        "24.50",
+		"MARY\u200bJONES":                     "MARYJONES",
+		"":                                    "",
+	} {
+		if got := normalizeCellText(in); got != want {
+			t.Errorf("normalizeCellText(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestExtractPlayerStatsFromTableColspanHeaders(t *testing.T) {
+	page := `<table>
+<tr><th>Player</th><th>SancPd</th><th>Games</th><th>Wins</th><th>PPD</th><th>MPR</th><th>Hat</th><th>High</th><th>Out</th></tr>
+<tr><td colspan="9">Team: Bridge Inn #1</td></tr>
+<tr><td>JOHN SMITH</td><td>A</td><td>10</td><td>6</td><td>24.50</td><td>2.10</td><td>1</td><td>140</td><td>96</td></tr>
+<tr><td>MARY JONES</td><td>B</td><td>8</td><td>3</td><td>18.20</td><td>1.60</td><td>0</td><td>100</td><td>40</td></tr>
+<tr><td colspan="9"> Sir James Pub Dos </td></tr>
+<tr><td>TOM NG</td><td>C</td><td>9</td><td>4</td><td>20.10</td><td>1.90</td><td>0</td><td>120</td><td>60</td></tr>
+</table>`
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(page))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	playerStats, _ := extractPlayerStatsFromTable(doc, "", DefaultParserConfig())
+	want := map[string]string{"JOHN SMITH": "BRIDGE INN 1", "MARY JONES": "BRIDGE INN 1", "TOM NG": "SIR JAMES PUB 2"}
+	if len(playerStats) != len(want) {
+		t.Fatalf("extractPlayerStatsFromTable() found %d players, want %d: %+v", len(playerStats), len(want), playerStats)
+	}
+	for _, player := range playerStats {
+		if player.Team != want[player.PlayerName] {
+			t.Errorf("%s is on team %q, want %q", player.PlayerName, player.Team, want[player.PlayerName])
+		}
+	}
+}
+
+func TestParsePlayerStatsLineNames(t *testing.T) {
+	tests := []struct {
+		line     string
+		wantName string
+		wantSanc string
+	}{
+		{"MIKE 10 7 25.3 2.1 3 140 96", "MIKE", ""},
+		{"JOHN SMITH 10 7 25.3 2.1 3 140 96", "JOHN SMITH", ""},
+		{"MARY JO ANNE 10 7 25.3 2.1 3 140 96", "MARY JO ANNE", ""},
+		{"JOHN SMITH A 10 7 25.3 2.1 3 140 96", "JOHN SMITH", "A"},
+		{"MIKE BB 10 7 25.3 2.1 3 140 96", "MIKE", "BB"},
+
+		// Short last names are not ratings
+		{"MARY JO 10 7 25.3 2.1 3 140 96", "MARY JO", ""},
+		{"AMY LEE 10 7 25.3 2.1 3 140 96", "AMY LEE", ""},
+		{"TOM NG CC 10 7 25.3 2.1 3 140 96", "TOM NG", "CC"},
+	}
+
+	for _, tt := range tests {
+		got, _ := parsePlayerStatsLine(tt.line, DefaultParserConfig())
+		if got.PlayerName != tt.wantName || got.SancPd != tt.wantSanc {
+			t.Errorf("parsePlayerStatsLine(%q) = name %q, sanc %q; want %q, %q",
+				tt.line, got.PlayerName, got.SancPd, tt.wantName, tt.wantSanc)
+		}
+		if got.GamesPlayed != 10 || got.GamesWon != 7 || got.PPD != 25.3 {
+			t.Errorf("parsePlayerStatsLine(%q) stats = %d games, %d wins, %v PPD; want 10, 7, 25.3",
+				tt.line, got.GamesPlayed, got.GamesWon, got.PPD)
+		}
+	}
+}
+
+func TestParsePlayerStatsLineSeedNumber(t *testing.T) {
+	tests := []struct {
+		line     string
+		wantName string
+		wantSanc string
+		wantSeed int
+		wantPPD  float64
+	}{
+		{"12 JOHN SMITH AA 10 7 25.3 2.1 3", "JOHN SMITH", "AA", 12, 25.3},
+		{"JOHN SMITH AA 10 7 25.3 2.1 3", "JOHN SMITH", "AA", 0, 25.3},
+		{"7 MIKE 10 7 25.3 2.1 3 1", "MIKE", "", 7, 25.3},
+		{"MIKE 10 7 25.3 2.1 3 1 0", "MIKE", "", 0, 25.3},
+	}
+
+	for _, tt := range tests {
+		got, _ := parsePlayerStatsLine(tt.line, DefaultParserConfig())
+		if got.PlayerName != tt.wantName || got.SancPd != tt.wantSanc || got.SeedNumber != tt.wantSeed || got.PPD != tt.wantPPD {
+			t.Errorf("parsePlayerStatsLine(%q) = name %q, sanc %q, seed %d, PPD %v; want %q, %q, %d, %v",
+				tt.line, got.PlayerName, got.SancPd, got.SeedNumber, got.PPD, tt.wantName, tt.wantSanc, tt.wantSeed, tt.wantPPD)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package parser
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// schedulePDF builds a one-page PDF showing each line of text
+func schedulePDF(lines ...string) []byte {
+	var content strings.Builder
+	content.WriteString("BT /F1 12 Tf 14 TL 72 720 Td\n")
+	for _, line := range lines {
+		fmt.Fprintf(&content, "(%s) Tj T*\n", line)
+	}
+	content.WriteString("ET")
+
+	objects := []string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R] /Count 1 >>",
+		"<< /Type /Page /Parent 2 0 R /MediaBox [0 0 612 792] /Resources << /Font << /F1 4 0 R >> >> /Contents 5 0 R >>",
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>",
+		fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", content.Len()+1, content.String()),
+	}
+
+	var pdf bytes.Buffer
+	pdf.WriteString("%PDF-1.4\n")
+	offsets := make([]int, len(objects))
+	for i, object := range objects {
+		offsets[i] = pdf.Len()
+		fmt.Fprintf(&pdf, "%d 0 obj\n%s\nendobj\n", i+1, object)
+	}
+	xref := pdf.Len()
+	fmt.Fprintf(&pdf, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&pdf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&pdf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xref)
+	return pdf.Bytes()
+}
+
+func TestReadPDFFromReader(t *testing.T) {
+	data := schedulePDF("Week 1 - October 5, 2024", "HARBOR HILLS vs REDHEADS")
+
+	text, err := ReadPDFFromReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("ReadPDFFromReader() error = %v", err)
+	}
+	if !strings.Contains(text, "HARBOR HILLS vs REDHEADS") {
+		t.Errorf("ReadPDFFromReader() = %q, want the schedule text", text)
+	}
+}
+
+func TestReadPDFText(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "schedule.pdf")
+	if err := os.WriteFile(path, schedulePDF("Week 2 - October 12, 2024", "THE HUTCH vs GRAND AVE"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	text, err := ReadPDFText(path)
+	if err != nil {
+		t.Fatalf("ReadPDFText() error = %v", err)
+	}
+	schedules := ExtractScheduleFromText(text)
+	if len(schedules) != 1 || schedules[0].Week != 2 || schedules[0].HomeTeam != "THE HUTCH" || schedules[0].AwayTeam != "GRAND AVE" {
+		t.Errorf("schedule from PDF = %+v, want week 2 THE HUTCH vs GRAND AVE", schedules)
+	}
+}
+
+func TestReadPDFFromReaderInvalid(t *testing.T) {
+	data := []byte("not a PDF")
+	if _, err := ReadPDFFromReader(bytes.NewReader(data), int64(len(data))); err == nil {
+		t.Error("ReadPDFFromReader() of a non-PDF succeeded")
+	}
+}
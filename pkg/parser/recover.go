@@ -0,0 +1,44 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/myusername/dart-statistic-scraper/pkg/models"
+)
+
+// ErrParsePanic is matched by errors returned when parsing a page panicked
+var ErrParsePanic = errors.New("panic while parsing page")
+
+// ParsePanicError records a panic recovered while parsing a standings page
+type ParsePanicError struct {
+	Week  int
+	URL   string
+	Value interface{}
+}
+
+func (e *ParsePanicError) Error() string {
+	return fmt.Sprintf("week %d (%s): %v: %v", e.Week, e.URL, ErrParsePanic, e.Value)
+}
+
+// Unwrap lets errors.Is match ErrParsePanic
+func (e *ParsePanicError) Unwrap() error {
+	return ErrParsePanic
+}
+
+// ExtractPlayerStatsSafe extracts player statistics like ExtractPlayerStatsWithConfig,
+// but recovers from a panic on malformed HTML and returns it as a *ParsePanicError
+// carrying the week and URL, so one bad page doesn't stop a whole run
+func ExtractPlayerStatsSafe(htmlContent string, config ParserConfig, week int, url string) (playerStats []models.PlayerStat, teamStats []models.TeamStat, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("Recovered from panic parsing week %d (%s): %v", week, url, r)
+			playerStats, teamStats = nil, nil
+			err = &ParsePanicError{Week: week, URL: url, Value: r}
+		}
+	}()
+
+	playerStats, teamStats = ExtractPlayerStatsWithConfig(htmlContent, config)
+	return playerStats, teamStats, nil
+}
@@ -0,0 +1,85 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/myusername/dart-statistic-scraper/pkg/models"
+)
+
+// MergeSchedules combines schedules from two sources. A team's match in a week
+// comes from primary when primary has one, and from secondary otherwise, so a
+// corrected schedule can override a parsed one. Teams are matched after
+// normalization. The result is sorted by week.
+func MergeSchedules(primary, secondary []models.MatchSchedule) []models.MatchSchedule {
+	type weekTeam struct {
+		week int
+		team string
+	}
+
+	scheduled := make(map[weekTeam]bool)
+	merged := make([]models.MatchSchedule, 0, len(primary)+len(secondary))
+	for _, match := range primary {
+		scheduled[weekTeam{match.Week, NormalizeTeamName(match.HomeTeam)}] = true
+		scheduled[weekTeam{match.Week, NormalizeTeamName(match.AwayTeam)}] = true
+		merged = append(merged, match)
+	}
+
+	for _, match := range secondary {
+		home := weekTeam{match.Week, NormalizeTeamName(match.HomeTeam)}
+		away := weekTeam{match.Week, NormalizeTeamName(match.AwayTeam)}
+		if scheduled[home] || scheduled[away] {
+			continue
+		}
+		scheduled[home] = true
+		scheduled[away] = true
+		merged = append(merged, match)
+	}
+
+	sort.SliceStable(merged, func(i, j int) bool {
+		return merged[i].Week < merged[j].Week
+	})
+	return merged
+}
+
+// ErrInvalidMatchDate is returned when a schedule date isn't in "Month D, YYYY" form
+var ErrInvalidMatchDate = errors.New("invalid match date")
+
+// monthPrefixes maps the first three letters of a month name to the month
+var monthPrefixes = map[string]time.Month{
+	"jan": time.January, "feb": time.February, "mar": time.March,
+	"apr": time.April, "may": time.May, "jun": time.June,
+	"jul": time.July, "aug": time.August, "sep": time.September,
+	"oct": time.October, "nov": time.November, "dec": time.December,
+}
+
+// ParseMatchDate parses a schedule date in "Month D, YYYY" form, such as
+// "October 5, 2024". Month names may be abbreviated ("Oct", "Sept.") and any
+// case, and the comma is optional. Placeholder dates like "Week 3, 2024"
+// return ErrInvalidMatchDate.
+func ParseMatchDate(s string) (time.Time, error) {
+	fields := strings.Fields(normalizeDate(s))
+	if len(fields) != 3 || len(fields[0]) < 3 {
+		return time.Time{}, fmt.Errorf("%q: %w", s, ErrInvalidMatchDate)
+	}
+
+	// The month may be abbreviated but not misspelled
+	month, found := monthPrefixes[fields[0][:3]]
+	found = found && strings.HasPrefix(strings.ToLower(month.String()), fields[0])
+	day, dayErr := strconv.Atoi(fields[1])
+	year, yearErr := strconv.Atoi(fields[2])
+	if !found || dayErr != nil || yearErr != nil || len(fields[2]) != 4 {
+		return time.Time{}, fmt.Errorf("%q: %w", s, ErrInvalidMatchDate)
+	}
+
+	// Reject days the month doesn't have rather than rolling them over
+	date := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	if date.Day() != day || date.Month() != month {
+		return time.Time{}, fmt.Errorf("%q: %w", s, ErrInvalidMatchDate)
+	}
+	return date, nil
+}
@@ -0,0 +1,168 @@
+package parser
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/myusername/dart-statistic-scraper/pkg/models"
+)
+
+func TestParseMatchDate(t *testing.T) {
+	tests := []struct {
+		in   string
+		want time.Time
+	}{
+		{"October 5, 2024", time.Date(2024, time.October, 5, 0, 0, 0, 0, time.UTC)},
+		{"Oct 5, 2024", time.Date(2024, time.October, 5, 0, 0, 0, 0, time.UTC)},
+		{"Sept. 12, 2024", time.Date(2024, time.September, 12, 0, 0, 0, 0, time.UTC)},
+		{"SEPTEMBER 12 2024", time.Date(2024, time.September, 12, 0, 0, 0, 0, time.UTC)},
+		{"february 29, 2024", time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC)},
+		{"Dec 31, 2024", time.Date(2024, time.December, 31, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseMatchDate(tt.in)
+		if err != nil || !got.Equal(tt.want) {
+			t.Errorf("ParseMatchDate(%q) = %v, %v; want %v", tt.in, got, err, tt.want)
+		}
+	}
+}
+
+func TestParseMatchDateInvalid(t *testing.T) {
+	for _, in := range []string{"Week 3, 2024", "February 30, 2024", "Octember 5, 2024", "October 5, 24", ""} {
+		if _, err := ParseMatchDate(in); !errors.Is(err, ErrInvalidMatchDate) {
+			t.Errorf("ParseMatchDate(%q) error = %v, want ErrInvalidMatchDate", in, err)
+		}
+	}
+}
+
+func TestExtractScheduleFromTextParsesDates(t *testing.T) {
+	text := "Week 1 - October 5, 2024\nHARBOR HILLS vs REDHEADS\n"
+	schedules := ExtractScheduleFromText(text)
+	if len(schedules) != 1 {
+		t.Fatalf("ExtractScheduleFromText() = %+v, want one match", schedules)
+	}
+	want := time.Date(2024, time.October, 5, 0, 0, 0, 0, time.UTC)
+	if schedules[0].Date != "October 5, 2024" || !schedules[0].ParsedDate.Equal(want) {
+		t.Errorf("match date = %q, %v; want %q, %v", schedules[0].Date, schedules[0].ParsedDate, "October 5, 2024", want)
+	}
+
+	for _, match := range ParseScheduleManually() {
+		if !match.ParsedDate.IsZero() {
+			t.Errorf("placeholder date %q parsed as %v", match.Date, match.ParsedDate)
+			break
+		}
+	}
+}
+
+func TestFindOpponentBye(t *testing.T) {
+	schedules := []models.MatchSchedule{
+		{Week: 3, HomeTeam: "HARBOR HILLS", AwayTeam: "REDHEADS"},
+		{Week: 4, HomeTeam: "HARBOR HILLS", AwayTeam: ByeTeam},
+		{Week: 5, HomeTeam: "bye", AwayTeam: "REDHEADS"},
+	}
+
+	tests := []struct {
+		team string
+		week int
+		want string
+	}{
+		{"Harbor Hills", 3, "REDHEADS"},
+		{"Harbor Hills", 4, ByeTeam},
+		{"REDHEADS", 4, "Unknown"},
+		{"REDHEADS", 5, ByeTeam},
+	}
+	for _, tt := range tests {
+		if got := FindOpponent(tt.team, tt.week, schedules); got != tt.want {
+			t.Errorf("FindOpponent(%q, %d) = %q, want %q", tt.team, tt.week, got, tt.want)
+		}
+	}
+}
+
+func TestExtractScheduleFromTextByeLines(t *testing.T) {
+	text := "Week 4 - October 26, 2024\nTHE HUTCH - BYE\nHARBOR HILLS: Bye\nREDHEADS vs SPEARS N BEERS\n"
+	schedules := ExtractScheduleFromText(text)
+
+	byes := make(map[string]bool)
+	for _, match := range schedules {
+		if match.AwayTeam == ByeTeam {
+			byes[match.HomeTeam] = true
+		}
+	}
+	if len(schedules) != 3 || !byes["THE HUTCH"] || !byes["HARBOR HILLS"] {
+		t.Errorf("ExtractScheduleFromText() = %+v, want BYEs for THE HUTCH and HARBOR HILLS plus one match", schedules)
+	}
+	if got := FindOpponent("THE HUTCH", 4, schedules); got != ByeTeam {
+		t.Errorf("FindOpponent(THE HUTCH) = %q, want %q", got, ByeTeam)
+	}
+}
+
+func TestExtractScheduleFromTextMixedCase(t *testing.T) {
+	text := `Week 2 - October 12, 2024
+Bridge Inn 1 vs Sir James Pub 2
+Harbor Hills @ Redheads
+The Hutch at Spears N Beers
+HILLS HAS EYES vs. GRAND AVE
+`
+	want := []models.MatchSchedule{
+		{HomeTeam: "BRIDGE INN 1", AwayTeam: "SIR JAMES PUB 2"},
+		{HomeTeam: "HARBOR HILLS", AwayTeam: "REDHEADS"},
+		{HomeTeam: "THE HUTCH", AwayTeam: "SPEARS N BEERS"},
+		{HomeTeam: "HILLS HAS EYES", AwayTeam: "GRAND AVE"},
+	}
+
+	schedules := ExtractScheduleFromText(text)
+	if len(schedules) != len(want) {
+		t.Fatalf("ExtractScheduleFromText() = %+v, want %d matches", schedules, len(want))
+	}
+	for i, match := range schedules {
+		if match.Week != 2 || match.HomeTeam != want[i].HomeTeam || match.AwayTeam != want[i].AwayTeam {
+			t.Errorf("match %d = week %d, %q vs %q; want week 2, %q vs %q",
+				i, match.Week, match.HomeTeam, match.AwayTeam, want[i].HomeTeam, want[i].AwayTeam)
+		}
+	}
+}
+
+func TestFindOpponentInDivision(t *testing.T) {
+	schedules := []models.MatchSchedule{
+		{Week: 3, HomeTeam: "HARBOR HILLS", AwayTeam: "REDHEADS", Division: "Monday A"},
+		{Week: 3, HomeTeam: "THE HUTCH", AwayTeam: "HARBOR HILLS", Division: "Tuesday B"},
+		{Week: 4, HomeTeam: "HARBOR HILLS", AwayTeam: "GRAND AVE"},
+	}
+
+	tests := []struct {
+		division string
+		week     int
+		want     string
+	}{
+		{"Monday A", 3, "REDHEADS"},
+		{"tuesday b", 3, "THE HUTCH"},
+		{"Wednesday C", 3, "Unknown"},
+		// Matchups without a division apply to every division
+		{"Tuesday B", 4, "GRAND AVE"},
+		// No division takes the first match
+		{"", 3, "REDHEADS"},
+	}
+	for _, tt := range tests {
+		if got := FindOpponentInDivision("Harbor Hills", tt.week, tt.division, schedules); got != tt.want {
+			t.Errorf("FindOpponentInDivision(week %d, %q) = %q, want %q", tt.week, tt.division, got, tt.want)
+		}
+	}
+}
+
+func TestExtractScheduleFromTextDivisions(t *testing.T) {
+	text := `Week 3 - October 19, 2024
+Division: Monday A
+HARBOR HILLS vs REDHEADS
+Division: Tuesday B
+THE HUTCH vs HARBOR HILLS
+`
+	schedules := ExtractScheduleFromText(text)
+	if len(schedules) != 2 || schedules[0].Division != "Monday A" || schedules[1].Division != "Tuesday B" {
+		t.Fatalf("ExtractScheduleFromText() = %+v, want one match in each division", schedules)
+	}
+	if got := FindOpponentInDivision("HARBOR HILLS", 3, "Tuesday B", schedules); got != "THE HUTCH" {
+		t.Errorf("FindOpponentInDivision() = %q, want THE HUTCH", got)
+	}
+}
@@ -0,0 +1,115 @@
+package parser
+
+import (
+	"log"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+
+	"github.com/myusername/dart-statistic-scraper/pkg/models"
+)
+
+// extractPlayerStatsFromSplitTables handles layouts that put the player names
+// and ratings in one table and their stats in a second table beside it. Each
+// names table (headed "Player" without a "PPD" column) is paired with the next
+// stats table (with "PPD" but no "Player"), and their rows are zipped by index.
+// Layouts without PPD, such as CricketColumns, look for "MPR" instead.
+func extractPlayerStatsFromSplitTables(doc *goquery.Document, defaultTeam string, config ParserConfig) ([]models.PlayerStat, []CellDiagnostic) {
+	var playerStats []models.PlayerStat
+	var diagnostics []CellDiagnostic
+
+	var namesTable *goquery.Selection
+	doc.Find("table").Each(func(i int, table *goquery.Selection) {
+		header := normalizeCellText(table.Find("tr").First().Text())
+		hasPlayer := strings.Contains(header, "Player")
+		hasPPD := strings.Contains(header, config.statsHeader())
+
+		switch {
+		case hasPlayer && !hasPPD:
+			namesTable = table
+		case hasPPD && !hasPlayer && namesTable != nil:
+			players, tableDiagnostics := zipSplitTables(namesTable, table, defaultTeam, config)
+			playerStats = append(playerStats, players...)
+			diagnostics = append(diagnostics, tableDiagnostics...)
+			namesTable = nil
+		}
+	})
+
+	return playerStats, diagnostics
+}
+
+// zipSplitTables combines the data rows of a names table and a stats table
+func zipSplitTables(namesTable, statsTable *goquery.Selection, defaultTeam string, config ParserConfig) ([]models.PlayerStat, []CellDiagnostic) {
+	var playerStats []models.PlayerStat
+	var diagnostics []CellDiagnostic
+
+	nameRows := tableRows(namesTable)
+	statRows := tableRows(statsTable)
+	if len(nameRows) != len(statRows) {
+		log.Printf("Warning: names table has %d rows but stats table has %d; extra rows are ignored",
+			len(nameRows), len(statRows))
+	}
+
+	// Split the layout into the columns of each table
+	var identityColumns []Column
+	for _, column := range config.Columns {
+		if column == ColumnPlayer || column == ColumnSancPd {
+			identityColumns = append(identityColumns, column)
+		}
+	}
+	valueColumns := config.valueColumns()
+
+	currentTeam := defaultTeam
+	for i := 0; i < len(nameRows) && i < len(statRows); i++ {
+		names := nameRows[i]
+		if len(names) == 0 || names[0] == "" {
+			continue
+		}
+
+		// Team rows carry just the team name
+		if len(names) == 1 && isTeamNameLine(names[0]) {
+			currentTeam = extractTeamName(names[0])
+			log.Printf("Found team name row: %s", currentTeam)
+			continue
+		}
+		if strings.Contains(names[0], "Team Totals") {
+			continue
+		}
+
+		playerStat := models.PlayerStat{Team: currentTeam}
+		for j, column := range identityColumns {
+			if j < len(names) {
+				assignCell(&playerStat, column, names[j], config, &diagnostics)
+			}
+		}
+		for j, column := range valueColumns {
+			if j < len(statRows[i]) {
+				assignCell(&playerStat, column, statRows[i][j], config, &diagnostics)
+			}
+		}
+
+		if playerStat.PlayerName != "" {
+			playerStats = append(playerStats, playerStat)
+			log.Printf("Added player from split tables: %s (Team: %s, PPD: %.2f)",
+				playerStat.PlayerName, playerStat.Team, playerStat.PPD)
+		}
+	}
+
+	return playerStats, diagnostics
+}
+
+// tableRows returns the trimmed cell texts of each row after the header row
+func tableRows(table *goquery.Selection) [][]string {
+	var rows [][]string
+	table.Find("tr").Each(func(i int, row *goquery.Selection) {
+		if i == 0 {
+			return
+		}
+		var cells []string
+		row.Find("td").Each(func(j int, cell *goquery.Selection) {
+			cells = append(cells, normalizeCellText(cell.Text()))
+		})
+		rows = append(rows, cells)
+	})
+	return rows
+}
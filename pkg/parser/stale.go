@@ -0,0 +1,38 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrStaleStandings is returned when a standings page still shows an earlier week
+var ErrStaleStandings = errors.New("standings page is stale")
+
+// CheckStaleStandings compares the week and date stated in the body of a
+// standings page against the week expected to be current. It returns an error
+// wrapping ErrStaleStandings when the page reports a different week, or the
+// expected week with a different date. An empty expectedDate skips the date check.
+func CheckStaleStandings(htmlContent string, expectedWeek int, expectedDate string) error {
+	pageWeek, pageDate := ExtractWeekAndDate(htmlContent)
+	if pageWeek == 0 {
+		// Nothing stated in the page to compare against
+		return nil
+	}
+
+	if pageWeek != expectedWeek {
+		return fmt.Errorf("page shows week %d but week %d is current: %w", pageWeek, expectedWeek, ErrStaleStandings)
+	}
+
+	if expectedDate != "" && pageDate != "" && normalizeDate(pageDate) != normalizeDate(expectedDate) {
+		return fmt.Errorf("page is dated %s but week %d is scheduled for %s: %w", pageDate, expectedWeek, expectedDate, ErrStaleStandings)
+	}
+
+	return nil
+}
+
+// normalizeDate reduces a date like "Sept. 8 , 2024" to a comparable form
+func normalizeDate(date string) string {
+	date = strings.NewReplacer(",", " ", ".", " ").Replace(strings.ToLower(date))
+	return strings.Join(strings.Fields(date), " ")
+}
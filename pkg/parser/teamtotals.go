@@ -0,0 +1,44 @@
+package parser
+
+import (
+	"github.com/myusername/dart-statistic-scraper/pkg/models"
+)
+
+// ComputeTeamStatsFromPlayers derives team totals for pages without a "Team
+// Totals" row: games played and won are summed and PPD and MPR are averaged
+// weighted by games played. Teams are listed in the order they first appear;
+// players without a team are skipped.
+func ComputeTeamStatsFromPlayers(players []models.PlayerStat) []models.TeamStat {
+	var teamStats []models.TeamStat
+	index := make(map[string]int)
+	ppdTotals := make(map[string]float64)
+	mprTotals := make(map[string]float64)
+
+	for _, player := range players {
+		if player.Team == "" {
+			continue
+		}
+
+		i, found := index[player.Team]
+		if !found {
+			i = len(teamStats)
+			index[player.Team] = i
+			teamStats = append(teamStats, models.TeamStat{TeamName: player.Team})
+		}
+
+		teamStats[i].GamesPlayed += player.GamesPlayed
+		teamStats[i].GamesWon += player.GamesWon
+		teamStats[i].DartsThrown += player.DartsThrown
+		ppdTotals[player.Team] += player.PPD * float64(player.GamesPlayed)
+		mprTotals[player.Team] += player.MPR * float64(player.GamesPlayed)
+	}
+
+	for i := range teamStats {
+		if games := teamStats[i].GamesPlayed; games > 0 {
+			teamStats[i].PPD = ppdTotals[teamStats[i].TeamName] / float64(games)
+			teamStats[i].MPR = mprTotals[teamStats[i].TeamName] / float64(games)
+		}
+	}
+
+	return teamStats
+}
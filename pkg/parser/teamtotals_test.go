@@ -0,0 +1,56 @@
+package parser
+
+import (
+	"math"
+	"testing"
+
+	"github.com/myusername/dart-statistic-scraper/pkg/models"
+)
+
+func TestComputeTeamStatsFromPlayers(t *testing.T) {
+	players := []models.PlayerStat{
+		{PlayerName: "JOHN SMITH", Team: "HARBOR HILLS", GamesPlayed: 10, GamesWon: 6, PPD: 24, MPR: 2.0},
+		{PlayerName: "MARY JONES", Team: "REDHEADS", GamesPlayed: 8, GamesWon: 3, PPD: 18, MPR: 1.5},
+		{PlayerName: "TOM NG", Team: "HARBOR HILLS", GamesPlayed: 5, GamesWon: 1, PPD: 15, MPR: 3.5},
+		{PlayerName: "AMY LEE", Team: "", GamesPlayed: 4, GamesWon: 4, PPD: 40},
+		{PlayerName: "BOB RAY", Team: "THE HUTCH"},
+	}
+
+	// Harbor Hills: PPD (10*24 + 5*15) / 15 = 21, MPR (10*2.0 + 5*3.5) / 15 = 2.5
+	want := []models.TeamStat{
+		{TeamName: "HARBOR HILLS", GamesPlayed: 15, GamesWon: 7, PPD: 21, MPR: 2.5},
+		{TeamName: "REDHEADS", GamesPlayed: 8, GamesWon: 3, PPD: 18, MPR: 1.5},
+		{TeamName: "THE HUTCH"},
+	}
+
+	got := ComputeTeamStatsFromPlayers(players)
+	if len(got) != len(want) {
+		t.Fatalf("ComputeTeamStatsFromPlayers() = %+v, want %+v", got, want)
+	}
+	for i := range want {
+		g, w := got[i], want[i]
+		if g.TeamName != w.TeamName || g.GamesPlayed != w.GamesPlayed || g.GamesWon != w.GamesWon ||
+			math.Abs(g.PPD-w.PPD) > 1e-9 || math.Abs(g.MPR-w.MPR) > 1e-9 {
+			t.Errorf("team %d = %+v, want %+v", i, g, w)
+		}
+	}
+}
+
+func TestExtractPlayerStatsComputesMissingTeamTotals(t *testing.T) {
+	page := `<html><body>
+<p>Combined X01/Cricket games, sorted by Team + PPD:</p>
+<table>
+<tr><th>Player</th><th>SancPd</th><th>Games</th><th>Wins</th><th>PPD</th><th>MPR</th><th>Hat</th><th>High</th><th>Out</th></tr>
+<tr><td colspan="9">REDHEADS</td></tr>
+<tr><td>JOHN SMITH</td><td>A</td><td>10</td><td>6</td><td>24.00</td><td>2.00</td><td>1</td><td>140</td><td>96</td></tr>
+<tr><td>MARY JONES</td><td>B</td><td>10</td><td>4</td><td>18.00</td><td>1.00</td><td>0</td><td>100</td><td>40</td></tr>
+</table>
+<p>Most Improved Players for week</p>
+</body></html>`
+
+	_, teamStats := ExtractPlayerStats(page)
+	if len(teamStats) != 1 || teamStats[0].TeamName != "REDHEADS" || teamStats[0].GamesPlayed != 20 ||
+		teamStats[0].GamesWon != 10 || teamStats[0].PPD != 21 || teamStats[0].MPR != 1.5 {
+		t.Errorf("team stats without a Team Totals row = %+v, want REDHEADS 20 games, 10 wins, 21 PPD, 1.5 MPR", teamStats)
+	}
+}
@@ -0,0 +1,22 @@
+package scraper
+
+import "context"
+
+// CachingFetcher fetches HTML pages like FetchURL, but keeps them in its own
+// cache directory rather than CacheDir. Later fetches of the same URL send the
+// cached ETag and Last-Modified values as a conditional request, and a 304 Not
+// Modified response is served from the cache.
+type CachingFetcher struct {
+	cache pageCache
+}
+
+// NewCachingFetcher returns a fetcher caching responses in dir on OutputFS
+func NewCachingFetcher(dir string) *CachingFetcher {
+	return &CachingFetcher{cache: pageCache(dir)}
+}
+
+// Fetch returns the HTML content of a URL, from the cache when the server
+// reports it unchanged. In offline mode only cached pages are served.
+func (c *CachingFetcher) Fetch(url string) (string, error) {
+	return fetchHTML(context.Background(), c.cache, url, DefaultMaxRetries, DefaultRetryDelay)
+}
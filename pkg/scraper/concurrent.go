@@ -0,0 +1,68 @@
+package scraper
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+
+	"github.com/myusername/dart-statistic-scraper/pkg/models"
+	"github.com/myusername/dart-statistic-scraper/pkg/parser"
+)
+
+// ProcessStandingsPagesConcurrent fetches and parses standings pages using at
+// most maxConcurrency requests at a time (1 when maxConcurrency <= 0). Each
+// page's week comes from its URL, or from the page when the URL has none, and
+// the page is parsed as ParseStandingsPage parses it with options. Results are
+// ordered by week
+// whatever order the pages finish in. Pages that fail are skipped and their
+// errors are returned together once the others are done.
+func ProcessStandingsPagesConcurrent(urls []string, maxConcurrency int, options parser.PageOptions) ([]*models.WeeklyStats, error) {
+	if maxConcurrency <= 0 {
+		maxConcurrency = 1
+	}
+
+	results := make([]*models.WeeklyStats, len(urls))
+	errs := make([]error, len(urls))
+
+	sem := make(chan struct{}, maxConcurrency)
+	var wg sync.WaitGroup
+	for i, url := range urls {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			results[i], errs[i] = processStandingsPage(url, options)
+		}()
+	}
+	wg.Wait()
+
+	var weeklyStats []*models.WeeklyStats
+	for _, result := range results {
+		if result != nil {
+			weeklyStats = append(weeklyStats, result)
+		}
+	}
+	sort.SliceStable(weeklyStats, func(i, j int) bool {
+		return weeklyStats[i].Week < weeklyStats[j].Week
+	})
+
+	return weeklyStats, errors.Join(errs...)
+}
+
+// processStandingsPage fetches and parses a single standings page
+func processStandingsPage(url string, options parser.PageOptions) (*models.WeeklyStats, error) {
+	htmlContent, err := FetchURL(url)
+	if err != nil {
+		return nil, fmt.Errorf("error scraping %s: %w", url, err)
+	}
+
+	weeklyStats, err := parser.ParseStandingsPage(htmlContent, ExtractWeekNumber(url), url, options)
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("Processed week %d from %s: %d players", weeklyStats.Week, url, len(weeklyStats.PlayerStats))
+	return weeklyStats, nil
+}
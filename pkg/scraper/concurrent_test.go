@@ -0,0 +1,55 @@
+package scraper
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/myusername/dart-statistic-scraper/pkg/models"
+	"github.com/myusername/dart-statistic-scraper/pkg/parser"
+)
+
+func TestProcessStandingsPagesConcurrent(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		fmt.Fprint(w, `<html><body>
+<p>Combined X01/Cricket games, sorted by Team + PPD:</p>
+<table>
+<tr><th>Player</th><th>SancPd</th><th>Games</th><th>Wins</th><th>PPD</th><th>MPR</th><th>Hat</th><th>High</th><th>Out</th></tr>
+<tr><td colspan="9">HARBOR HILLS</td></tr>
+<tr><td>JOHN SMITH</td><td>A</td><td>10</td><td>6</td><td>24.50</td><td>2.10</td><td>1</td><td>140</td><td>96</td></tr>
+</table>
+</body></html>`)
+	}))
+	defer server.Close()
+	useMemoryCache(t, "")
+
+	options := parser.PageOptions{
+		Config:   parser.DefaultParserConfig(),
+		Division: "SUN2",
+		Schedules: []models.MatchSchedule{
+			{Week: 2, Date: "September 28, 2024", HomeTeam: "HARBOR HILLS", AwayTeam: "REDHEADS", Division: "SUN1"},
+			{Week: 2, Date: "September 28, 2024", HomeTeam: "HARBOR HILLS", AwayTeam: "BULLSEYES", Division: "SUN2"},
+		},
+		Finish: func(weeklyStats *models.WeeklyStats) *models.WeeklyStats {
+			weeklyStats.Segment = "first"
+			return weeklyStats
+		},
+	}
+	weeks, err := ProcessStandingsPagesConcurrent([]string{server.URL + "/Wk2.html", server.URL + "/Wk1.html"}, 2, options)
+	if err != nil {
+		t.Fatalf("ProcessStandingsPagesConcurrent: %v", err)
+	}
+	if len(weeks) != 2 || weeks[0].Week != 1 || weeks[1].Week != 2 {
+		t.Fatalf("ProcessStandingsPagesConcurrent returned %d weeks, want weeks 1 and 2 in order", len(weeks))
+	}
+
+	week2 := weeks[1]
+	if week2.Division != "SUN2" || week2.Date != "September 28, 2024" || week2.Segment != "first" {
+		t.Errorf("week 2 division %q, date %q, segment %q; want SUN2, September 28, 2024, first", week2.Division, week2.Date, week2.Segment)
+	}
+	if len(week2.PlayerStats) != 1 || week2.PlayerStats[0].Opponent != "BULLSEYES" {
+		t.Errorf("week 2 players = %+v, want JOHN SMITH playing BULLSEYES", week2.PlayerStats)
+	}
+}
@@ -0,0 +1,66 @@
+package scraper
+
+import (
+	"log"
+	"sort"
+)
+
+// CrawlStandings fetches startURL and returns the standings links found on
+// it, following each standings page's own standings links, such as
+// "continued" or "next week" pages, up to maxDepth levels deep. Each URL is
+// visited at most once. The links are absolute and sorted by week number.
+func CrawlStandings(startURL string, maxDepth int) ([]string, error) {
+	htmlContent, err := FetchURL(startURL)
+	if err != nil {
+		return nil, err
+	}
+	return CrawlStandingsPage(startURL, htmlContent, maxDepth), nil
+}
+
+// CrawlStandingsPage is like CrawlStandings for a start page that has
+// already been fetched. Pages that fail to fetch are logged and skipped.
+func CrawlStandingsPage(pageURL, htmlContent string, maxDepth int) []string {
+	visited := map[string]bool{pageURL: true}
+	var links []string
+
+	// Breadth-first over the standings pages, one level per depth
+	queue := standingsLinksOf(pageURL, htmlContent, visited)
+	links = append(links, queue...)
+	for depth := 1; depth <= maxDepth && len(queue) > 0; depth++ {
+		var next []string
+		for _, link := range queue {
+			content, err := FetchURL(link)
+			if err != nil {
+				log.Printf("Error crawling %s: %v", link, err)
+				continue
+			}
+			found := standingsLinksOf(link, content, visited)
+			if len(found) > 0 {
+				log.Printf("Found %d more standings links on %s", len(found), link)
+			}
+			next = append(next, found...)
+		}
+		links = append(links, next...)
+		queue = next
+	}
+
+	sort.SliceStable(links, func(i, j int) bool {
+		return ExtractWeekNumber(links[i]) < ExtractWeekNumber(links[j])
+	})
+	return links
+}
+
+// standingsLinksOf returns the absolute standings links on a page that are
+// not yet in visited, marking them visited
+func standingsLinksOf(pageURL, htmlContent string, visited map[string]bool) []string {
+	var links []string
+	for _, link := range ExtractStandingsLinks(htmlContent) {
+		absURL := ResolveRelativeURL(pageURL, link)
+		if visited[absURL] {
+			continue
+		}
+		visited[absURL] = true
+		links = append(links, absURL)
+	}
+	return links
+}
@@ -0,0 +1,48 @@
+package scraper
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// readBody reads a response body, decompressing it when the server sent it
+// gzip or deflate encoded. The transport only does this itself when it set
+// Accept-Encoding, which custom headers can prevent.
+func readBody(resp *http.Response) ([]byte, error) {
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	encoding := strings.ToLower(strings.TrimSpace(resp.Header.Get("Content-Encoding")))
+	switch encoding {
+	case "gzip", "x-gzip":
+		reader, err := gzip.NewReader(bytes.NewReader(body))
+		if err != nil {
+			return nil, fmt.Errorf("error decoding gzip body: %w", err)
+		}
+		defer reader.Close()
+		return io.ReadAll(reader)
+	case "deflate":
+		// Most servers send zlib-wrapped data, but some send raw deflate
+		if reader, err := zlib.NewReader(bytes.NewReader(body)); err == nil {
+			defer reader.Close()
+			return io.ReadAll(reader)
+		}
+		reader := flate.NewReader(bytes.NewReader(body))
+		defer reader.Close()
+		decoded, err := io.ReadAll(reader)
+		if err != nil {
+			return nil, fmt.Errorf("error decoding deflate body: %w", err)
+		}
+		return decoded, nil
+	}
+
+	return body, nil
+}
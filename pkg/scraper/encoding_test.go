@@ -0,0 +1,56 @@
+package scraper
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestFetchURLDecompressesBody(t *testing.T) {
+	const page = "<html><body>Week 5 standings</body></html>"
+	encoders := map[string]func(io.Writer) io.WriteCloser{
+		"gzip":         func(w io.Writer) io.WriteCloser { return gzip.NewWriter(w) },
+		"deflate":      func(w io.Writer) io.WriteCloser { return zlib.NewWriter(w) },
+		"raw deflate":  func(w io.Writer) io.WriteCloser { fw, _ := flate.NewWriter(w, flate.DefaultCompression); return fw },
+		"uncompressed": nil,
+	}
+
+	for name, encoder := range encoders {
+		var body bytes.Buffer
+		encoding := ""
+		if encoder != nil {
+			w := encoder(&body)
+			io.WriteString(w, page)
+			w.Close()
+			encoding = map[string]string{"gzip": "gzip", "deflate": "deflate", "raw deflate": "deflate"}[name]
+		} else {
+			body.WriteString(page)
+		}
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			if encoding != "" {
+				w.Header().Set("Content-Encoding", encoding)
+			}
+			w.Write(body.Bytes())
+		}))
+
+		// Asking for compression ourselves stops the transport decoding it
+		useMemoryCache(t, "")
+		oldHeaders := ExtraHeaders
+		ExtraHeaders = http.Header{"Accept-Encoding": {"gzip, deflate"}}
+
+		got, err := FetchURL(server.URL)
+		if err != nil || got != page {
+			t.Errorf("%s: FetchURL() = %q, %v; want the decoded page", name, got, err)
+		}
+
+		ExtraHeaders = oldHeaders
+		server.Close()
+	}
+}
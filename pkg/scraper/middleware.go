@@ -0,0 +1,391 @@
+package scraper
+
+import (
+	"fmt"
+	"log"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// Middleware wraps a RoundTripper with cross-cutting behaviour such as logging
+type Middleware func(next http.RoundTripper) http.RoundTripper
+
+// RoundTripperFunc adapts an ordinary function to an http.RoundTripper
+type RoundTripperFunc func(req *http.Request) (*http.Response, error)
+
+// RoundTrip calls f(req)
+func (f RoundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// Chain wraps base with the middlewares. The first middleware is the outermost,
+// so it sees each request first and each response last.
+func Chain(base http.RoundTripper, middlewares ...Middleware) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		base = middlewares[i](base)
+	}
+	return base
+}
+
+// LoggingMiddleware logs the method, URL, status and duration of every request
+func LoggingMiddleware() Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			start := time.Now()
+			resp, err := next.RoundTrip(req)
+			if err != nil {
+				log.Printf("%s %s failed after %v: %v", req.Method, req.URL, time.Since(start), err)
+				return nil, err
+			}
+			log.Printf("%s %s -> %d in %v", req.Method, req.URL, resp.StatusCode, time.Since(start))
+			return resp, nil
+		})
+	}
+}
+
+// HeaderMiddleware adds headers to every request, without replacing headers the
+// request already sets
+func HeaderMiddleware(headers http.Header) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			if len(headers) > 0 {
+				req = req.Clone(req.Context())
+				for name, values := range headers {
+					if req.Header.Get(name) != "" {
+						continue
+					}
+					for _, value := range values {
+						req.Header.Add(name, value)
+					}
+				}
+			}
+			return next.RoundTrip(req)
+		})
+	}
+}
+
+// UserAgent is sent as the User-Agent header of every request made by
+// FetchURL and DownloadPDF
+var UserAgent = "dart-statistic-scraper/1.0"
+
+// ExtraHeaders are added to every request made by FetchURL and DownloadPDF,
+// e.g. a Cookie the league site requires
+var ExtraHeaders = http.Header{}
+
+// identityMiddleware sets UserAgent and ExtraHeaders on every request, reading
+// them when each request is sent so later changes take effect
+func identityMiddleware() Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			headers := ExtraHeaders.Clone()
+			if headers == nil {
+				headers = http.Header{}
+			}
+			if UserAgent != "" && headers.Get("User-Agent") == "" {
+				headers.Set("User-Agent", UserAgent)
+			}
+			return HeaderMiddleware(headers)(next).RoundTrip(req)
+		})
+	}
+}
+
+// Metrics counts the requests that pass through MetricsMiddleware. It is safe
+// for concurrent use.
+type Metrics struct {
+	mu            sync.Mutex
+	requests      int
+	failures      int
+	statusCounts  map[int]int
+	totalDuration time.Duration
+}
+
+// MetricsSnapshot is a point-in-time copy of Metrics
+type MetricsSnapshot struct {
+	Requests      int
+	Failures      int
+	StatusCounts  map[int]int
+	TotalDuration time.Duration
+}
+
+// Snapshot returns a copy of the current counts
+func (m *Metrics) Snapshot() MetricsSnapshot {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	snapshot := MetricsSnapshot{
+		Requests:      m.requests,
+		Failures:      m.failures,
+		StatusCounts:  make(map[int]int, len(m.statusCounts)),
+		TotalDuration: m.totalDuration,
+	}
+	for status, count := range m.statusCounts {
+		snapshot.StatusCounts[status] = count
+	}
+	return snapshot
+}
+
+// String summarizes the counts for logging
+func (m *Metrics) String() string {
+	s := m.Snapshot()
+	return fmt.Sprintf("%d requests, %d failures, %v total, statuses %v", s.Requests, s.Failures, s.TotalDuration, s.StatusCounts)
+}
+
+// record adds one request to the counts
+func (m *Metrics) record(status int, duration time.Duration, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.requests++
+	m.totalDuration += duration
+	if err != nil {
+		m.failures++
+		return
+	}
+	if m.statusCounts == nil {
+		m.statusCounts = make(map[int]int)
+	}
+	m.statusCounts[status]++
+}
+
+// MetricsMiddleware records every request in m
+func MetricsMiddleware(m *Metrics) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			start := time.Now()
+			resp, err := next.RoundTrip(req)
+			status := 0
+			if resp != nil {
+				status = resp.StatusCode
+			}
+			m.record(status, time.Since(start), err)
+			return resp, err
+		})
+	}
+}
+
+// isRetryableStatus reports whether a response status is worth retrying
+func isRetryableStatus(status int) bool {
+	switch status {
+	case http.StatusInternalServerError, http.StatusBadGateway,
+		http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// RetryMiddleware retries requests that fail with a connection error or a
+// 500/502/503/504 response, up to maxRetries times, waiting baseDelay doubled on
+// each attempt plus jitter. Other statuses, such as 404 and 403, are returned
+// as is. Requests with a body are only retried when it can be replayed.
+func RetryMiddleware(maxRetries int, baseDelay time.Duration) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			for attempt := 0; ; attempt++ {
+				attemptReq := req
+				if attempt > 0 && req.Body != nil {
+					if req.GetBody == nil {
+						return nil, fmt.Errorf("cannot retry request to %s: body can't be replayed", req.URL)
+					}
+					body, err := req.GetBody()
+					if err != nil {
+						return nil, err
+					}
+					attemptReq = req.Clone(req.Context())
+					attemptReq.Body = body
+				}
+
+				resp, err := next.RoundTrip(attemptReq)
+				retryable := err != nil || isRetryableStatus(resp.StatusCode)
+				if !retryable || attempt >= maxRetries {
+					return resp, err
+				}
+
+				// Back off exponentially with up to 50% jitter
+				delay := baseDelay << attempt
+				if delay > 0 {
+					delay += time.Duration(rand.Int63n(int64(delay)/2 + 1))
+				}
+				if err != nil {
+					log.Printf("Retrying %s after error (attempt %d of %d, waiting %v): %v", req.URL, attempt+1, maxRetries, delay, err)
+				} else {
+					log.Printf("Retrying %s after status %d (attempt %d of %d, waiting %v)", req.URL, resp.StatusCode, attempt+1, maxRetries, delay)
+					resp.Body.Close()
+				}
+
+				select {
+				case <-time.After(delay):
+				case <-req.Context().Done():
+					return nil, req.Context().Err()
+				}
+			}
+		})
+	}
+}
+
+// RateLimiter spaces requests at least a minimum interval apart. A single
+// limiter can be shared by several middlewares and goroutines.
+type RateLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	next     time.Time
+}
+
+// NewRateLimiter returns a limiter allowing one request per interval
+func NewRateLimiter(interval time.Duration) *RateLimiter {
+	return &RateLimiter{interval: interval}
+}
+
+// SetInterval changes the minimum interval between requests, including the
+// wait before the next one
+func (l *RateLimiter) SetInterval(interval time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if !l.next.IsZero() {
+		l.next = l.next.Add(interval - l.interval)
+	}
+	l.interval = interval
+}
+
+// Wait blocks until the next request may be sent, or the request is canceled
+func (l *RateLimiter) Wait(req *http.Request) error {
+	l.mu.Lock()
+	now := time.Now()
+	wait := l.next.Sub(now)
+	if wait < 0 {
+		wait = 0
+	}
+	l.next = now.Add(wait + l.interval)
+	l.mu.Unlock()
+
+	if wait == 0 {
+		return nil
+	}
+	select {
+	case <-time.After(wait):
+		return nil
+	case <-req.Context().Done():
+		return req.Context().Err()
+	}
+}
+
+// RateLimitMiddleware waits for the limiter before sending each request
+func RateLimitMiddleware(limiter *RateLimiter) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			if err := limiter.Wait(req); err != nil {
+				return nil, err
+			}
+			return next.RoundTrip(req)
+		})
+	}
+}
+
+// DefaultMetrics counts the requests made by FetchURL and DownloadPDF
+var DefaultMetrics = &Metrics{}
+
+// DefaultRateLimiter spaces out the requests made by FetchURL, DownloadPDF and
+// CachingFetcher, including those made concurrently
+var DefaultRateLimiter = NewRateLimiter(500 * time.Millisecond)
+
+// SetRequestInterval changes the minimum interval between requests made by
+// FetchURL, DownloadPDF and CachingFetcher; zero removes the limit
+func SetRequestInterval(d time.Duration) {
+	DefaultRateLimiter.SetInterval(d)
+}
+
+// DefaultMiddlewares returns the middleware chain used by FetchURL and DownloadPDF
+func DefaultMiddlewares() []Middleware {
+	return []Middleware{
+		RateLimitMiddleware(DefaultRateLimiter),
+		LoggingMiddleware(),
+		MetricsMiddleware(DefaultMetrics),
+		identityMiddleware(),
+	}
+}
+
+// HTTPTimeout limits how long a single request made by FetchURL or
+// DownloadPDF may take, unless the client given to SetHTTPClient has its own
+// timeout
+var HTTPTimeout = 30 * time.Second
+
+// SetHTTPTimeout changes HTTPTimeout, e.g. for large PDFs on a slow connection
+func SetHTTPTimeout(d time.Duration) {
+	HTTPTimeout = d
+}
+
+// httpClient is shared by FetchURL and DownloadPDF and sends every request
+// through the default middleware chain. http.DefaultTransport takes its proxy
+// from the HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables.
+var httpClient = &http.Client{
+	Transport: Chain(http.DefaultTransport, DefaultMiddlewares()...),
+}
+
+// baseClient is the client httpClient was built from, before the middleware
+var baseClient = &http.Client{}
+
+// SetHTTPClient makes FetchURL and DownloadPDF send requests with a copy of
+// client, such as one configured with a proxy or custom TLS settings. Its
+// transport (http.DefaultTransport if nil) is wrapped in the default
+// middleware chain, and a non-zero Timeout overrides HTTPTimeout.
+func SetHTTPClient(client *http.Client) {
+	transport := client.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	base := *client
+	baseClient = &base
+
+	c := *client
+	c.Transport = Chain(transport, DefaultMiddlewares()...)
+	httpClient = &c
+}
+
+// SetProxy sends requests through the proxy at proxyURL, e.g.
+// "http://proxy.example.com:3128", instead of the one named by the HTTP_PROXY
+// and HTTPS_PROXY environment variables. An empty proxyURL goes back to the
+// environment. The client given to SetHTTPClient keeps its other settings, but
+// must use an *http.Transport.
+func SetProxy(proxyURL string) error {
+	transport := http.DefaultTransport.(*http.Transport)
+	if baseClient.Transport != nil {
+		custom, ok := baseClient.Transport.(*http.Transport)
+		if !ok {
+			return fmt.Errorf("cannot set a proxy on a %T transport", baseClient.Transport)
+		}
+		transport = custom
+	}
+	transport = transport.Clone()
+
+	transport.Proxy = http.ProxyFromEnvironment
+	if proxyURL != "" {
+		proxy, err := url.Parse(proxyURL)
+		if err != nil {
+			return fmt.Errorf("invalid proxy URL: %w", err)
+		}
+		if proxy.Scheme == "" || proxy.Host == "" {
+			return fmt.Errorf("invalid proxy URL %q: expected scheme://host:port", proxyURL)
+		}
+		transport.Proxy = http.ProxyURL(proxy)
+	}
+
+	c := *baseClient
+	c.Transport = transport
+	SetHTTPClient(&c)
+	return nil
+}
+
+// requestClient returns the client for a request, with its timeout applied
+func requestClient() *http.Client {
+	c := *httpClient
+	if c.Timeout == 0 {
+		c.Timeout = HTTPTimeout
+	}
+	return &c
+}
@@ -0,0 +1,104 @@
+package scraper
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// useDefaultClient restores the default HTTP client and timeout after a test
+func useDefaultClient(t *testing.T) {
+	t.Helper()
+	useMemoryCache(t, "")
+	t.Cleanup(func() {
+		SetHTTPClient(&http.Client{})
+		SetHTTPTimeout(30 * time.Second)
+	})
+}
+
+// slowServer answers after delay, or gives up when the client does
+func slowServer(delay time.Duration) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(delay):
+		case <-r.Context().Done():
+			return
+		}
+		w.Header().Set("Content-Type", "text/html")
+		fmt.Fprint(w, "<html>slow</html>")
+	}))
+}
+
+func TestSetHTTPClientTimeout(t *testing.T) {
+	useDefaultClient(t)
+	server := slowServer(time.Second)
+	defer server.Close()
+
+	SetHTTPClient(&http.Client{Timeout: 20 * time.Millisecond})
+	start := time.Now()
+	if _, err := FetchURLWithRetry(server.URL, 0, 0); err == nil {
+		t.Error("FetchURLWithRetry() against a slow server succeeded, want a timeout")
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("request took %v, want it cut short by the client timeout", elapsed)
+	}
+}
+
+func TestSetHTTPTimeout(t *testing.T) {
+	useDefaultClient(t)
+	server := slowServer(100 * time.Millisecond)
+	defer server.Close()
+
+	SetHTTPTimeout(20 * time.Millisecond)
+	if _, err := FetchURLWithRetry(server.URL, 0, 0); err == nil {
+		t.Error("FetchURLWithRetry() with a short HTTPTimeout succeeded, want a timeout")
+	}
+
+	SetHTTPTimeout(5 * time.Second)
+	if content, err := FetchURLWithRetry(server.URL, 0, 0); err != nil || content != "<html>slow</html>" {
+		t.Errorf("FetchURLWithRetry() with a long HTTPTimeout = %q, %v", content, err)
+	}
+}
+
+func TestSetProxy(t *testing.T) {
+	useDefaultClient(t)
+
+	// The proxy answers for a host that doesn't exist, so the page can only
+	// come through it
+	var proxied []string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = append(proxied, r.URL.String())
+		w.Header().Set("Content-Type", "text/html")
+		fmt.Fprint(w, "<html>via proxy</html>")
+	}))
+	defer proxy.Close()
+
+	if err := SetProxy(proxy.URL); err != nil {
+		t.Fatal(err)
+	}
+	const target = "http://league.invalid/standings/Wk5.html"
+	content, err := FetchURLWithRetry(target, 0, 0)
+	if err != nil || content != "<html>via proxy</html>" {
+		t.Fatalf("FetchURLWithRetry() through the proxy = %q, %v", content, err)
+	}
+	if len(proxied) != 1 || proxied[0] != target {
+		t.Errorf("proxy saw %v, want one request for %s", proxied, target)
+	}
+}
+
+func TestSetProxyErrors(t *testing.T) {
+	useDefaultClient(t)
+
+	for _, proxyURL := range []string{"proxy.example.com:3128", "http://", "://bad"} {
+		if err := SetProxy(proxyURL); err == nil {
+			t.Errorf("SetProxy(%q) succeeded, want an error", proxyURL)
+		}
+	}
+
+	SetHTTPClient(&http.Client{Transport: RoundTripperFunc(http.DefaultTransport.RoundTrip)})
+	if err := SetProxy("http://proxy.example.com:3128"); err == nil {
+		t.Error("SetProxy() on a custom transport succeeded, want an error")
+	}
+}
@@ -0,0 +1,111 @@
+package scraper
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"net/http"
+	"path/filepath"
+	"time"
+
+	"github.com/myusername/dart-statistic-scraper/pkg/vfs"
+)
+
+// ErrOfflineMiss is returned in offline mode for anything that isn't cached
+var ErrOfflineMiss = errors.New("not cached and offline mode is enabled")
+
+// CacheDir, when set, is where FetchURL keeps a copy of every page it fetches,
+// keyed by a hash of the URL, so the page can be served again in offline mode
+// and isn't downloaded again while the server reports it unchanged
+var CacheDir string
+
+// Offline stops FetchURL and DownloadPDF from making any network request.
+// FetchURL serves pages from CacheDir and returns ErrOfflineMiss for the rest.
+var Offline bool
+
+// pageCache is a directory of fetched pages, each saved as <hash>.html next to
+// a <hash>.json holding the validators it was served with. The empty
+// pageCache keeps nothing.
+type pageCache string
+
+// cacheEntry is the metadata stored next to each cached page
+type cacheEntry struct {
+	URL          string    `json:"url"`
+	ETag         string    `json:"etag,omitempty"`
+	LastModified string    `json:"lastModified,omitempty"`
+	FetchedAt    time.Time `json:"fetchedAt"`
+}
+
+// urlHash returns the hex SHA-256 of a URL, used to name cache files
+func urlHash(url string) string {
+	sum := sha256.Sum256([]byte(url))
+	return hex.EncodeToString(sum[:])
+}
+
+// path returns the cache file for a URL with the given extension
+func (c pageCache) path(url, ext string) string {
+	return filepath.Join(string(c), urlHash(url)+ext)
+}
+
+// read returns the cached copy of a page and its metadata. Pages cached
+// without metadata are still served, but never sent as conditional requests.
+func (c pageCache) read(url string) (string, cacheEntry, bool) {
+	var entry cacheEntry
+	if c == "" {
+		return "", entry, false
+	}
+	content, err := vfs.ReadFile(OutputFS, c.path(url, ".html"))
+	if err != nil {
+		return "", entry, false
+	}
+
+	data, err := vfs.ReadFile(OutputFS, c.path(url, ".json"))
+	if err == nil {
+		if err := json.Unmarshal(data, &entry); err != nil {
+			log.Printf("Ignoring corrupt cache entry for %s: %v", url, err)
+			entry = cacheEntry{}
+		}
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		log.Printf("Error reading cache entry for %s: %v", url, err)
+	}
+	return string(content), entry, true
+}
+
+// write stores a copy of a page and the validators from its response
+func (c pageCache) write(url, content string, header http.Header) error {
+	if c == "" {
+		return nil
+	}
+	if err := OutputFS.MkdirAll(string(c), 0755); err != nil {
+		return fmt.Errorf("error creating cache directory: %w", err)
+	}
+	if err := vfs.WriteFile(OutputFS, c.path(url, ".html"), []byte(content)); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(cacheEntry{
+		URL:          url,
+		ETag:         header.Get("ETag"),
+		LastModified: header.Get("Last-Modified"),
+		FetchedAt:    time.Now().UTC(),
+	}, "", "  ")
+	if err != nil {
+		return err
+	}
+	return vfs.WriteFile(OutputFS, c.path(url, ".json"), data)
+}
+
+// setConditionalHeaders asks the server to answer 304 Not Modified when the
+// page hasn't changed since it was cached
+func (e cacheEntry) setConditionalHeaders(req *http.Request) {
+	if e.ETag != "" {
+		req.Header.Set("If-None-Match", e.ETag)
+	}
+	if e.LastModified != "" {
+		req.Header.Set("If-Modified-Since", e.LastModified)
+	}
+}
@@ -0,0 +1,107 @@
+package scraper
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestFetchURLCachesOnlyWhenCacheDirIsSet(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "text/html")
+		fmt.Fprint(w, "<html>week 1</html>")
+	}))
+	defer server.Close()
+
+	fsys := useMemoryCache(t, "")
+	if _, err := FetchURL(server.URL); err != nil {
+		t.Fatal(err)
+	}
+	if files := fsys.Files(); len(files) != 0 {
+		t.Errorf("FetchURL without a cache directory wrote %v", files)
+	}
+
+	CacheDir = "cache"
+	if _, err := FetchURL(server.URL); err != nil {
+		t.Fatal(err)
+	}
+	if files := fsys.Files(); len(files) != 2 {
+		t.Errorf("FetchURL with a cache directory wrote %v, want one cached page and its metadata", files)
+	}
+
+	Offline = true
+	content, err := FetchURL(server.URL)
+	if err != nil || content != "<html>week 1</html>" {
+		t.Errorf("offline FetchURL = %q, %v; want the cached page", content, err)
+	}
+	if requests != 2 {
+		t.Errorf("server saw %d requests, want 2", requests)
+	}
+}
+
+func TestFetchURLOfflineMiss(t *testing.T) {
+	useMemoryCache(t, "cache")
+	Offline = true
+
+	if _, err := FetchURL("http://example.invalid/week1.html"); !errors.Is(err, ErrOfflineMiss) {
+		t.Errorf("offline FetchURL of an uncached page = %v, want ErrOfflineMiss", err)
+	}
+}
+
+func TestFetchURLRevalidatesCachedPages(t *testing.T) {
+	var conditional []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conditional = append(conditional, r.Header.Get("If-None-Match"))
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html")
+		w.Header().Set("ETag", `"v1"`)
+		fmt.Fprint(w, "<html>week 1</html>")
+	}))
+	defer server.Close()
+
+	useMemoryCache(t, "cache")
+	for i := 0; i < 2; i++ {
+		content, err := FetchURL(server.URL)
+		if err != nil || content != "<html>week 1</html>" {
+			t.Fatalf("fetch %d = %q, %v; want the page", i+1, content, err)
+		}
+	}
+	if len(conditional) != 2 || conditional[0] != "" || conditional[1] != `"v1"` {
+		t.Errorf("If-None-Match headers = %q, want none then the cached ETag", conditional)
+	}
+}
+
+func TestCachingFetcherKeepsItsOwnCache(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-Modified-Since") != "" {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html")
+		w.Header().Set("Last-Modified", "Sat, 05 Oct 2024 12:00:00 GMT")
+		fmt.Fprint(w, "<html>week 1</html>")
+	}))
+	defer server.Close()
+
+	fsys := useMemoryCache(t, "")
+	fetcher := NewCachingFetcher("pages")
+	for i := 0; i < 2; i++ {
+		content, err := fetcher.Fetch(server.URL)
+		if err != nil || content != "<html>week 1</html>" {
+			t.Fatalf("fetch %d = %q, %v; want the page", i+1, content, err)
+		}
+	}
+	for _, name := range fsys.Files() {
+		if !strings.HasPrefix(name, "pages") {
+			t.Errorf("CachingFetcher wrote %s outside its cache directory", name)
+		}
+	}
+}
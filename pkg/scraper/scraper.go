@@ -2,66 +2,184 @@
 package scraper
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"io"
 	"log"
+	"mime"
 	"net/http"
-	"os"
+	"net/url"
+	"path"
 	"regexp"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/PuerkitoBio/goquery"
+
+	"github.com/myusername/dart-statistic-scraper/pkg/vfs"
+)
+
+// OutputFS is the filesystem downloaded pages and PDFs are saved to
+var OutputFS vfs.FS = vfs.OS{}
+
+// Retry defaults used by FetchURL
+var (
+	DefaultMaxRetries = 3
+	DefaultRetryDelay = time.Second
 )
 
-// FetchURL downloads the HTML content from a URL and returns it as a string
+// ErrUnexpectedContentType is returned when a page fetched as HTML is something else
+var ErrUnexpectedContentType = errors.New("unexpected content type")
+
+// FetchURL downloads the HTML content from a URL and returns it as a string,
+// retrying transient failures with DefaultMaxRetries and DefaultRetryDelay.
+// Responses that aren't HTML, such as a redirect to a PDF, return
+// ErrUnexpectedContentType, and pages matching SoftNotFoundMarkers return
+// ErrSoftNotFound. In offline mode the page is served from CacheDir instead.
 func FetchURL(url string) (string, error) {
-	log.Printf("Fetching URL: %s", url)
+	return FetchURLContext(context.Background(), url)
+}
+
+// FetchURLContext is like FetchURL but stops retrying and aborts the request
+// when ctx is canceled or its deadline passes
+func FetchURLContext(ctx context.Context, url string) (string, error) {
+	return fetchHTML(ctx, pageCache(CacheDir), url, DefaultMaxRetries, DefaultRetryDelay)
+}
+
+// FetchURLWithMeta downloads a URL like FetchURL, but accepts any content type
+// and returns it alongside the body
+func FetchURLWithMeta(url string) (string, string, error) {
+	return fetchURL(context.Background(), pageCache(CacheDir), url, DefaultMaxRetries, DefaultRetryDelay)
+}
+
+// FetchURLWithRetry downloads the HTML content from a URL, retrying connection
+// errors and 500/502/503/504 responses up to maxRetries times with exponential
+// backoff starting at baseDelay. Other failures, such as 404 and 403, are
+// returned straight away.
+func FetchURLWithRetry(url string, maxRetries int, baseDelay time.Duration) (string, error) {
+	return fetchHTML(context.Background(), pageCache(CacheDir), url, maxRetries, baseDelay)
+}
+
+// retryingClient returns a client sending requests through the default chain
+// with retries, its timeout scaled to allow for every attempt
+func retryingClient(maxRetries int, baseDelay time.Duration) *http.Client {
+	client := requestClient()
+	client.Timeout *= time.Duration(maxRetries + 1)
+	client.Transport = Chain(client.Transport, RetryMiddleware(maxRetries, baseDelay))
+	return client
+}
 
-	// Create an HTTP client with a timeout
-	client := &http.Client{
-		Timeout: 30 * time.Second,
+// fetchHTML downloads a page through cache and checks that it is HTML
+func fetchHTML(ctx context.Context, cache pageCache, url string, maxRetries int, baseDelay time.Duration) (string, error) {
+	body, contentType, err := fetchURL(ctx, cache, url, maxRetries, baseDelay)
+	if err != nil {
+		return "", err
+	}
+	if !isHTMLContentType(contentType) {
+		return "", fmt.Errorf("%s: %w: %s", url, ErrUnexpectedContentType, contentType)
+	}
+	if err := checkSoftNotFound(url, body); err != nil {
+		return "", err
 	}
+	return body, nil
+}
 
-	// Send the HTTP request
-	resp, err := client.Get(url)
+// isHTMLContentType reports whether a Content-Type header names an HTML document
+func isHTMLContentType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
 	if err != nil {
-		return "", fmt.Errorf("error fetching URL: %w", err)
+		return false
+	}
+	return mediaType == "text/html" || mediaType == "application/xhtml+xml"
+}
+
+// fetchURL downloads a URL under ctx with the given retry settings and returns
+// the body and its content type. Servers that send no Content-Type have it
+// detected from the body. Only HTML is cached, and a cached page is served
+// again when the server answers 304 Not Modified.
+func fetchURL(ctx context.Context, cache pageCache, url string, maxRetries int, baseDelay time.Duration) (string, string, error) {
+	cached, entry, isCached := cache.read(url)
+	if Offline {
+		if isCached {
+			log.Printf("Using cached copy of %s", url)
+			return cached, "text/html", nil
+		}
+		return "", "", fmt.Errorf("%s: %w", url, ErrOfflineMiss)
+	}
+
+	log.Printf("Fetching URL: %s", url)
+
+	// Send the HTTP request through the default middleware chain, retrying
+	client := retryingClient(maxRetries, baseDelay)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", "", fmt.Errorf("error creating request: %w", err)
+	}
+	if isCached {
+		entry.setConditionalHeaders(req)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", "", fmt.Errorf("error fetching URL: %w", err)
 	}
 	defer resp.Body.Close()
 
 	// Check the response status code
 	log.Printf("HTTP Status: %d (%s)", resp.StatusCode, resp.Status)
+	if resp.StatusCode == http.StatusNotModified && isCached {
+		log.Printf("%s not modified, using cached copy", url)
+		return cached, "text/html", nil
+	}
 	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("non-200 status code: %d %s", resp.StatusCode, resp.Status)
+		return "", "", fmt.Errorf("non-200 status code: %d %s", resp.StatusCode, resp.Status)
 	}
 
 	// Read the response body
-	body, err := io.ReadAll(resp.Body)
+	body, err := readBody(resp)
 	if err != nil {
-		return "", fmt.Errorf("error reading response body: %w", err)
+		return "", "", fmt.Errorf("error reading response body: %w", err)
 	}
 
 	// Print some information about the response
 	contentType := resp.Header.Get("Content-Type")
 	contentLength := resp.Header.Get("Content-Length")
 	log.Printf("Content-Type: %s, Content-Length: %s bytes", contentType, contentLength)
+	if contentType == "" {
+		contentType = http.DetectContentType(body)
+	}
+
+	// Keep a copy of pages for offline runs
+	if isHTMLContentType(contentType) {
+		if err := cache.write(url, string(body), resp.Header); err != nil {
+			log.Printf("Error caching %s: %v", url, err)
+		}
+	}
 
-	return string(body), nil
+	return string(body), contentType, nil
 }
 
 // DownloadPDF downloads a PDF file from a URL and saves it locally
 func DownloadPDF(url string, localPath string) error {
-	log.Printf("Downloading PDF from %s to %s", url, localPath)
+	return DownloadPDFContext(context.Background(), url, localPath)
+}
 
-	// Create HTTP client with timeout
-	client := &http.Client{
-		Timeout: 30 * time.Second,
+// DownloadPDFContext is like DownloadPDF but aborts the download when ctx is
+// canceled or its deadline passes
+func DownloadPDFContext(ctx context.Context, url string, localPath string) error {
+	if Offline {
+		return fmt.Errorf("%s: %w", url, ErrOfflineMiss)
 	}
 
-	// Send the HTTP request
-	resp, err := client.Get(url)
+	log.Printf("Downloading PDF from %s to %s", url, localPath)
+
+	// Send the HTTP request through the default middleware chain
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("error creating request: %w", err)
+	}
+	resp, err := requestClient().Do(req)
 	if err != nil {
 		return fmt.Errorf("error fetching PDF: %w", err)
 	}
@@ -73,7 +191,7 @@ func DownloadPDF(url string, localPath string) error {
 	}
 
 	// Create the file
-	out, err := os.Create(localPath)
+	out, err := OutputFS.Create(localPath)
 	if err != nil {
 		return fmt.Errorf("error creating file: %w", err)
 	}
@@ -91,13 +209,26 @@ func DownloadPDF(url string, localPath string) error {
 
 // SaveContentToFile saves content to a file
 func SaveContentToFile(filename string, content string) error {
-	return os.WriteFile(filename, []byte(content), 0644)
+	return vfs.WriteFile(OutputFS, filename, []byte(content))
 }
 
+// SeasonPrefix is the season name standings links must contain, e.g. "Spring2025"
+var SeasonPrefix = "Fall2024"
+
+// StandingsLinkPattern, when set, replaces the SeasonPrefix check and selects
+// standings links by regular expression
+var StandingsLinkPattern *regexp.Regexp
+
 // ExtractStandingsLinks extracts links to individual standings pages
 func ExtractStandingsLinks(htmlContent string) []string {
 	var links []string
 
+	if StandingsLinkPattern != nil {
+		log.Printf("Matching standings links against pattern %s", StandingsLinkPattern)
+	} else {
+		log.Printf("Matching standings links for season %s", SeasonPrefix)
+	}
+
 	// Use goquery to parse the HTML content
 	doc, err := goquery.NewDocumentFromReader(strings.NewReader(htmlContent))
 	if err != nil {
@@ -113,7 +244,7 @@ func ExtractStandingsLinks(htmlContent string) []string {
 		}
 
 		// Only collect links that look like standings pages
-		if strings.Contains(href, "Fall2024") && strings.Contains(href, "Wk") {
+		if isStandingsLink(href) {
 			log.Printf("Found standings link: %s", href)
 			links = append(links, href)
 		}
@@ -123,41 +254,125 @@ func ExtractStandingsLinks(htmlContent string) []string {
 	return links
 }
 
-// ResolveRelativeURL resolves a relative URL to an absolute URL
-func ResolveRelativeURL(baseURL, relativeURL string) string {
-	// Check if the relative URL is already an absolute URL
-	if strings.HasPrefix(relativeURL, "http://") || strings.HasPrefix(relativeURL, "https://") {
-		return relativeURL
+// isStandingsLink reports whether a link points at a standings page: it must
+// match StandingsLinkPattern when set, and otherwise contain SeasonPrefix and
+// a week number
+func isStandingsLink(href string) bool {
+	if StandingsLinkPattern != nil {
+		return StandingsLinkPattern.MatchString(href)
+	}
+	return strings.Contains(href, SeasonPrefix) && ExtractWeekNumber(href) > 0
+}
+
+// ExtractScheduleLinks extracts links to schedule PDFs from an index page
+func ExtractScheduleLinks(htmlContent string) []string {
+	var links []string
+
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(htmlContent))
+	if err != nil {
+		log.Printf("Error parsing HTML content: %v", err)
+		return links
+	}
+
+	doc.Find("a").Each(func(i int, s *goquery.Selection) {
+		href, exists := s.Attr("href")
+		if !exists {
+			return
+		}
+
+		// Schedules are published as PDFs, so any PDF link is a candidate
+		if strings.HasSuffix(strings.ToLower(strings.TrimSpace(href)), ".pdf") {
+			log.Printf("Found schedule link: %s", href)
+			links = append(links, strings.TrimSpace(href))
+		}
+	})
+
+	log.Printf("Extracted %d schedule links", len(links))
+	return links
+}
+
+// NormalizeURL ensures a URL has correct protocol slashes and encoded spaces
+func NormalizeURL(rawURL string) string {
+	// Fix common protocol formatting issues
+	if strings.HasPrefix(rawURL, "https:/") && !strings.HasPrefix(rawURL, "https://") {
+		rawURL = "https://" + strings.TrimPrefix(rawURL, "https:/")
+	} else if strings.HasPrefix(rawURL, "http:/") && !strings.HasPrefix(rawURL, "http://") {
+		rawURL = "http://" + strings.TrimPrefix(rawURL, "http:/")
 	}
 
+	// Schedule PDFs often have spaces in their filenames; already encoded
+	// sequences like %20 are left untouched
+	return strings.ReplaceAll(rawURL, " ", "%20")
+}
+
+// ScheduleFilename returns a local filename for a schedule PDF URL, decoding
+// %20 and replacing spaces so the file is easy to work with on disk
+func ScheduleFilename(pdfURL string) string {
+	name := path.Base(pdfURL)
+	if idx := strings.IndexAny(name, "?#"); idx != -1 {
+		name = name[:idx]
+	}
+	if decoded, err := url.PathUnescape(name); err == nil {
+		name = decoded
+	}
+	name = strings.ReplaceAll(name, " ", "_")
+	if name == "" || name == "." || name == "/" {
+		name = "schedule.pdf"
+	}
+	return name
+}
+
+// ResolveRelativeURL resolves a link found on a page against the page's URL,
+// handling "../" and "./" segments, site-root-relative paths ("/x.html") and
+// scheme-relative URLs ("//host/x"). Absolute links are only normalized.
+func ResolveRelativeURL(baseURL, relativeURL string) string {
 	// Fix protocol in base URL if needed
-	if !strings.HasPrefix(baseURL, "https://") && !strings.HasPrefix(baseURL, "http://") {
+	if !strings.HasPrefix(baseURL, "https:/") && !strings.HasPrefix(baseURL, "http:/") {
 		// If no protocol, assume https
 		baseURL = "https://" + baseURL
-	} else if strings.HasPrefix(baseURL, "https:/") && !strings.HasPrefix(baseURL, "https://") {
-		// Fix malformed https:/ protocol (missing slash)
-		baseURL = "https://" + strings.TrimPrefix(baseURL, "https:/")
-	} else if strings.HasPrefix(baseURL, "http:/") && !strings.HasPrefix(baseURL, "http://") {
-		// Fix malformed http:/ protocol (missing slash)
-		baseURL = "http://" + strings.TrimPrefix(baseURL, "http:/")
 	}
+	baseURL = NormalizeURL(baseURL)
+	relativeURL = NormalizeURL(relativeURL)
 
-	// Get base directory by removing the filename component
-	baseDir := baseURL
-	lastSlashIndex := strings.LastIndex(baseURL, "/")
-	if lastSlashIndex > 0 && lastSlashIndex < len(baseURL)-1 {
-		baseDir = baseURL[:lastSlashIndex+1]
-	} else if !strings.HasSuffix(baseDir, "/") {
-		baseDir += "/"
+	base, err := url.Parse(baseURL)
+	if err != nil {
+		log.Printf("Error parsing base URL %s: %v", baseURL, err)
+		return relativeURL
 	}
-
-	// Combine with relative URL
-	return baseDir + relativeURL
+	ref, err := url.Parse(relativeURL)
+	if err != nil {
+		log.Printf("Error parsing link %s: %v", relativeURL, err)
+		return relativeURL
+	}
+	return base.ResolveReference(ref).String()
 }
 
-// ExtractWeekNumber extracts the week number from a URL
+// WeekPatterns are the regular expressions ExtractWeekNumber tries in order.
+// Each must capture the week number in its first group, and all are matched
+// case-insensitively. The defaults match "Wk5", "wk03", "Week-12", "Week_3"
+// and "_W3_"; leading zeros are ignored.
+var WeekPatterns = []string{`Wk(\d+)`, `Week[-_ ]?(\d+)`, `_W(\d+)_`}
+
+// ExtractWeekNumber extracts the week number from a URL using the first of
+// WeekPatterns that matches
 func ExtractWeekNumber(url string) int {
-	re := regexp.MustCompile(`Wk(\d+)`)
+	for _, pattern := range WeekPatterns {
+		re, err := regexp.Compile("(?i)" + pattern)
+		if err != nil {
+			log.Printf("Skipping invalid week pattern %q: %v", pattern, err)
+			continue
+		}
+
+		if weekNum := ExtractWeekNumberWith(url, re); weekNum > 0 {
+			return weekNum
+		}
+	}
+	return 0
+}
+
+// ExtractWeekNumberWith extracts the week number captured by the first group
+// of re, returning 0 when re doesn't match
+func ExtractWeekNumberWith(url string, re *regexp.Regexp) int {
 	matches := re.FindStringSubmatch(url)
 	if len(matches) > 1 {
 		weekNum, err := strconv.Atoi(matches[1])
@@ -0,0 +1,120 @@
+package scraper
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/myusername/dart-statistic-scraper/pkg/vfs"
+)
+
+// useMemoryCache sends the files a test writes to memory and removes the
+// request interval, restoring both afterwards
+func useMemoryCache(t *testing.T, cacheDir string) *vfs.MemFS {
+	t.Helper()
+	fsys := vfs.NewMemFS()
+	oldFS, oldCacheDir, oldOffline := OutputFS, CacheDir, Offline
+	OutputFS, CacheDir, Offline = fsys, cacheDir, false
+	SetRequestInterval(0)
+	t.Cleanup(func() {
+		OutputFS, CacheDir, Offline = oldFS, oldCacheDir, oldOffline
+		SetRequestInterval(500 * time.Millisecond)
+	})
+	return fsys
+}
+
+func TestExtractWeekNumber(t *testing.T) {
+	tests := []struct {
+		url  string
+		want int
+	}{
+		{"https://example.com/FALL2024Wk5.html", 5},
+		{"https://example.com/fall2024wk03.html", 3},
+		{"https://example.com/standings-Week-05.html", 5},
+		{"https://example.com/standings_week_12.html", 12},
+		{"https://example.com/Week 7.html", 7},
+		{"https://example.com/FALL2024_W3_standings.html", 3},
+		{"https://example.com/standings.html", 0},
+	}
+
+	for _, tt := range tests {
+		if got := ExtractWeekNumber(tt.url); got != tt.want {
+			t.Errorf("ExtractWeekNumber(%q) = %d, want %d", tt.url, got, tt.want)
+		}
+	}
+}
+
+func TestExtractWeekNumberCustomPatterns(t *testing.T) {
+	defaults := WeekPatterns
+	defer func() { WeekPatterns = defaults }()
+
+	WeekPatterns = []string{`Week-(\d+)`, `\bW(\d+)`, `wk_(\d+)`}
+	tests := []struct {
+		url  string
+		want int
+	}{
+		{"https://example.com/standings-Week-05.html", 5},
+		{"https://example.com/standings-week-12.html", 12},
+		{"https://example.com/FALL2024_W3_standings.html", 0},
+		{"https://example.com/standings/W3.html", 3},
+		{"https://example.com/standings_WK_7.html", 7},
+	}
+	for _, tt := range tests {
+		if got := ExtractWeekNumber(tt.url); got != tt.want {
+			t.Errorf("ExtractWeekNumber(%q) = %d, want %d", tt.url, got, tt.want)
+		}
+	}
+
+	// Invalid patterns are skipped and the first match wins
+	WeekPatterns = []string{`(`, `W(\d+)`, `wk_(\d+)`}
+	if got := ExtractWeekNumber("https://example.com/w5_wk_6.html"); got != 5 {
+		t.Errorf("ExtractWeekNumber() = %d, want 5", got)
+	}
+}
+
+func TestResolveRelativeURL(t *testing.T) {
+	base := "https://macdleagues.com/Standings/FALL2024/index.html"
+	tests := []struct {
+		base string
+		ref  string
+		want string
+	}{
+		{base, "FALL2024Wk5.html", "https://macdleagues.com/Standings/FALL2024/FALL2024Wk5.html"},
+		{base, "./FALL2024Wk5.html", "https://macdleagues.com/Standings/FALL2024/FALL2024Wk5.html"},
+		{base, "../SPRING2025/index.html", "https://macdleagues.com/Standings/SPRING2025/index.html"},
+		{base, "/root/x.html", "https://macdleagues.com/root/x.html"},
+		{base, "//mirror.example.com/x.html", "https://mirror.example.com/x.html"},
+		{base, "https://other.example.com/y.html", "https://other.example.com/y.html"},
+		{"https:/macdleagues.com/Standings/", "Wk1.html", "https://macdleagues.com/Standings/Wk1.html"},
+		{base, "https:/other.example.com/y.html", "https://other.example.com/y.html"},
+		{"macdleagues.com/Standings/", "Wk1.html", "https://macdleagues.com/Standings/Wk1.html"},
+	}
+
+	for _, tt := range tests {
+		if got := ResolveRelativeURL(tt.base, tt.ref); got != tt.want {
+			t.Errorf("ResolveRelativeURL(%q, %q) = %q, want %q", tt.base, tt.ref, got, tt.want)
+		}
+	}
+}
+
+func TestExtractWeekNumberWith(t *testing.T) {
+	tests := []struct {
+		url     string
+		pattern string
+		want    int
+	}{
+		{"https://example.com/FALL2024Wk5.html", `Wk(\d+)`, 5},
+		{"https://example.com/fall2024wk03.html", `(?i)wk(\d+)`, 3},
+		{"https://example.com/Week-012.html", `Week-(\d+)`, 12},
+		{"https://example.com/round-9.html", `round-(\d+)`, 9},
+		// No match, or no capture group, is week 0
+		{"https://example.com/standings.html", `Wk(\d+)`, 0},
+		{"https://example.com/FALL2024Wk5.html", `Wk\d+`, 0},
+	}
+
+	for _, tt := range tests {
+		if got := ExtractWeekNumberWith(tt.url, regexp.MustCompile(tt.pattern)); got != tt.want {
+			t.Errorf("ExtractWeekNumberWith(%q, %q) = %d, want %d", tt.url, tt.pattern, got, tt.want)
+		}
+	}
+}
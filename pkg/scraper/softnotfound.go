@@ -0,0 +1,32 @@
+package scraper
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrSoftNotFound is returned when a page is served with status 200 but its
+// content is a "page not found" template
+var ErrSoftNotFound = errors.New("page not found")
+
+// SoftNotFoundMarkers are case-insensitive phrases, like "Page Not Found",
+// that mark a fetched page as missing even though the server returned it
+// successfully. Empty by default, which disables the check.
+var SoftNotFoundMarkers []string
+
+// checkSoftNotFound returns ErrSoftNotFound if the page contains any of
+// SoftNotFoundMarkers
+func checkSoftNotFound(url, body string) error {
+	if len(SoftNotFoundMarkers) == 0 {
+		return nil
+	}
+
+	lowerBody := strings.ToLower(body)
+	for _, marker := range SoftNotFoundMarkers {
+		if marker != "" && strings.Contains(lowerBody, strings.ToLower(marker)) {
+			return fmt.Errorf("%s: %w: page contains %q", url, ErrSoftNotFound, marker)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+package stats
+
+import (
+	"github.com/myusername/dart-statistic-scraper/pkg/models"
+)
+
+// Award is a season award won by a player. Value is the winning figure, e.g.
+// the season PPD or, for most improved, the PPD gained.
+type Award struct {
+	Player models.PlayerSeasonStat `json:"player"`
+	Value  float64                 `json:"value"`
+}
+
+// Awards is the end-of-season awards summary. An award is nil when no player
+// qualified for it. TeamMVPs is keyed by normalized team name.
+type Awards struct {
+	HighestPPD    *Award                             `json:"highestPpd,omitempty"`
+	HighestMPR    *Award                             `json:"highestMpr,omitempty"`
+	MostHatTricks *Award                             `json:"mostHatTricks,omitempty"`
+	BestCheckout  *Award                             `json:"bestCheckout,omitempty"`
+	MostImproved  *Award                             `json:"mostImproved,omitempty"`
+	TeamMVPs      map[string]models.PlayerSeasonStat `json:"teamMvps"`
+}
+
+// SeasonAwards computes the end-of-season awards. Only players with at least
+// MinQualifyingGames season games are eligible for any award.
+func SeasonAwards(weeks []*models.WeeklyStats) Awards {
+	var qualified []models.PlayerSeasonStat
+	for _, player := range aggregatePlayers(weeks) {
+		if player.GamesPlayed >= MinQualifyingGames {
+			qualified = append(qualified, player)
+		}
+	}
+
+	return Awards{
+		HighestPPD:    bestAward(qualified, MetricPPD),
+		HighestMPR:    bestAward(qualified, MetricMPR),
+		MostHatTricks: bestAward(qualified, MetricHatTricks),
+		BestCheckout:  bestAward(qualified, MetricHighCheckout),
+		MostImproved:  mostImprovedAward(weeks, qualified),
+		TeamMVPs:      TeamMVPs(weeks, MetricPPD),
+	}
+}
+
+// bestAward returns the player ranking first on metric, or nil if there are
+// no players or the best value is zero
+func bestAward(players []models.PlayerSeasonStat, metric string) *Award {
+	var best *models.PlayerSeasonStat
+	for i := range players {
+		if best == nil || betterSeason(players[i], *best, metric) {
+			best = &players[i]
+		}
+	}
+	if best == nil || metricValue(*best, metric) <= 0 {
+		return nil
+	}
+	return &Award{Player: *best, Value: metricValue(*best, metric)}
+}
+
+// mostImprovedAward compares each qualified player's PPD over the first half
+// of the season with the second half and returns the largest gain
+func mostImprovedAward(weeks []*models.WeeklyStats, qualified []models.PlayerSeasonStat) *Award {
+	sorted := sortedWeeks(weeks)
+	if len(sorted) < 2 {
+		return nil
+	}
+	half := len(sorted) / 2
+
+	early := make(map[string]models.PlayerSeasonStat)
+	for _, player := range aggregatePlayers(sorted[:half]) {
+		early[seasonKey(player)] = player
+	}
+	late := make(map[string]models.PlayerSeasonStat)
+	for _, player := range aggregatePlayers(sorted[half:]) {
+		late[seasonKey(player)] = player
+	}
+
+	var award *Award
+	for _, player := range qualified {
+		before, foundBefore := early[seasonKey(player)]
+		after, foundAfter := late[seasonKey(player)]
+		if !foundBefore || !foundAfter {
+			continue
+		}
+
+		gain := after.PPD - before.PPD
+		if gain > 0 && (award == nil || gain > award.Value) {
+			award = &Award{Player: player, Value: gain}
+		}
+	}
+	return award
+}
@@ -0,0 +1,97 @@
+package stats
+
+import (
+	"github.com/myusername/dart-statistic-scraper/pkg/models"
+	"github.com/myusername/dart-statistic-scraper/pkg/parser"
+)
+
+// AggregateStat is a player's cumulative statistics over a set of weeks
+type AggregateStat = models.PlayerSeasonStat
+
+// ClutchMargin is the largest score margin for a match to count as close
+var ClutchMargin = 1
+
+// ApplyMatchScores returns a copy of the schedule with each match's score set
+// from the games won by both teams that week. Matches that already have a score
+// keep it, and matches where either team has no stats for the week are left
+// without a score. Divisions scraped together may share week numbers, so the
+// score comes from whichever of the week's stats has both teams.
+func ApplyMatchScores(weeks []*models.WeeklyStats, schedules []models.MatchSchedule) []models.MatchSchedule {
+	byWeek := make(map[int][]*models.WeeklyStats)
+	for _, weeklyStats := range weeks {
+		if weeklyStats != nil {
+			byWeek[weeklyStats.Week] = append(byWeek[weeklyStats.Week], weeklyStats)
+		}
+	}
+
+	scored := make([]models.MatchSchedule, len(schedules))
+	for i, match := range schedules {
+		scored[i] = match
+		if match.HasScore {
+			continue
+		}
+
+		for _, weeklyStats := range byWeek[match.Week] {
+			home, homeFound := teamWeekStat(weeklyStats, parser.NormalizeTeamName(match.HomeTeam))
+			away, awayFound := teamWeekStat(weeklyStats, parser.NormalizeTeamName(match.AwayTeam))
+			if !homeFound || !awayFound {
+				continue
+			}
+			scored[i].HomeScore = home.GamesWon
+			scored[i].AwayScore = away.GamesWon
+			scored[i].HasScore = true
+			break
+		}
+	}
+	return scored
+}
+
+// ClutchStats aggregates players' stats over only the weeks where their team's
+// match was close, decided by at most ClutchMargin games. Only scored matches
+// are considered, so schedules usually come from ApplyMatchScores. The result is
+// keyed by normalized player name and team ("NAME|TEAM").
+func ClutchStats(weeks []*models.WeeklyStats, schedules []models.MatchSchedule) map[string]AggregateStat {
+	// Teams in a close match, by week
+	closeTeams := make(map[int]map[string]bool)
+	for _, match := range schedules {
+		if !match.HasScore {
+			continue
+		}
+		margin := match.HomeScore - match.AwayScore
+		if margin < 0 {
+			margin = -margin
+		}
+		if margin > ClutchMargin {
+			continue
+		}
+		if closeTeams[match.Week] == nil {
+			closeTeams[match.Week] = make(map[string]bool)
+		}
+		closeTeams[match.Week][parser.NormalizeTeamName(match.HomeTeam)] = true
+		closeTeams[match.Week][parser.NormalizeTeamName(match.AwayTeam)] = true
+	}
+
+	// Keep only the players whose team was in a close match that week
+	var clutchWeeks []*models.WeeklyStats
+	for _, weeklyStats := range sortedWeeks(weeks) {
+		teams := closeTeams[weeklyStats.Week]
+		if len(teams) == 0 {
+			continue
+		}
+
+		clutchWeek := *weeklyStats
+		clutchWeek.PlayerStats = nil
+		for _, player := range weeklyStats.PlayerStats {
+			if teams[parser.NormalizeTeamName(player.Team)] {
+				clutchWeek.PlayerStats = append(clutchWeek.PlayerStats, player)
+			}
+		}
+		clutchWeeks = append(clutchWeeks, &clutchWeek)
+	}
+
+	clutch := make(map[string]AggregateStat)
+	for _, player := range aggregatePlayers(clutchWeeks) {
+		clutch[normalizePlayerName(player.PlayerName)+"|"+parser.NormalizeTeamName(player.Team)] = player
+	}
+	return clutch
+}
@@ -0,0 +1,34 @@
+package stats
+
+import (
+	"testing"
+
+	"github.com/myusername/dart-statistic-scraper/pkg/models"
+)
+
+func TestApplyMatchScoresAcrossDivisions(t *testing.T) {
+	// Two divisions scraped together both have a week 2
+	weeks := []*models.WeeklyStats{
+		{Week: 2, Division: "SUN1", TeamStats: []models.TeamStat{
+			{TeamName: "HARBOR HILLS", GamesWon: 12},
+			{TeamName: "REDHEADS", GamesWon: 9},
+		}},
+		{Week: 2, Division: "SUN2", TeamStats: []models.TeamStat{
+			{TeamName: "BRIDGE INN 1", GamesWon: 8},
+			{TeamName: "SIR JAMES PUB", GamesWon: 13},
+		}},
+	}
+	schedules := []models.MatchSchedule{
+		{Week: 2, HomeTeam: "HARBOR HILLS", AwayTeam: "REDHEADS", Division: "SUN1"},
+		{Week: 2, HomeTeam: "BRIDGE INN 1", AwayTeam: "SIR JAMES PUB", Division: "SUN2"},
+	}
+
+	scored := ApplyMatchScores(weeks, schedules)
+	want := [][2]int{{12, 9}, {8, 13}}
+	for i, match := range scored {
+		if !match.HasScore || match.HomeScore != want[i][0] || match.AwayScore != want[i][1] {
+			t.Errorf("match %d score = %d-%d (scored %v), want %d-%d",
+				i, match.HomeScore, match.AwayScore, match.HasScore, want[i][0], want[i][1])
+		}
+	}
+}
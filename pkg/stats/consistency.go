@@ -0,0 +1,106 @@
+package stats
+
+import (
+	"math"
+
+	"github.com/myusername/dart-statistic-scraper/pkg/models"
+	"github.com/myusername/dart-statistic-scraper/pkg/parser"
+)
+
+// ConsistencyReport summarizes how much a team's performance varies week to week.
+// Lower standard deviations indicate a more reliable team.
+type ConsistencyReport struct {
+	Team         string
+	Weeks        int
+	MeanPPD      float64
+	StdDevPPD    float64
+	MeanMPR      float64
+	StdDevMPR    float64
+	MeanWinPct   float64
+	StdDevWinPct float64
+}
+
+// TeamConsistency computes the mean and standard deviation of a team's weekly
+// PPD, MPR and win percentage across the season. Weeks where the team didn't
+// play are ignored. Team names are matched after normalization.
+func TeamConsistency(weeks []*models.WeeklyStats, team string) ConsistencyReport {
+	report := ConsistencyReport{Team: team}
+	normTeam := parser.NormalizeTeamName(team)
+
+	var ppds, mprs, winPcts []float64
+	for _, weeklyStats := range sortedWeeks(weeks) {
+		teamStat, found := teamWeekStat(weeklyStats, normTeam)
+		if !found || teamStat.GamesPlayed == 0 {
+			continue
+		}
+		ppds = append(ppds, teamStat.PPD)
+		mprs = append(mprs, teamStat.MPR)
+		winPcts = append(winPcts, float64(teamStat.GamesWon)/float64(teamStat.GamesPlayed)*100)
+	}
+
+	report.Weeks = len(ppds)
+	report.MeanPPD, report.StdDevPPD = meanStdDev(ppds)
+	report.MeanMPR, report.StdDevMPR = meanStdDev(mprs)
+	report.MeanWinPct, report.StdDevWinPct = meanStdDev(winPcts)
+	return report
+}
+
+// TeamWeekTotals returns a team's totals for a week, from the page's team totals
+// row when present and derived from the team's players otherwise. Team names
+// are matched after normalization.
+func TeamWeekTotals(weeklyStats *models.WeeklyStats, team string) (models.TeamStat, bool) {
+	return teamWeekStat(weeklyStats, parser.NormalizeTeamName(team))
+}
+
+// teamWeekStat returns a team's totals for a week, using the page's team totals
+// row when present and deriving them from the team's players otherwise
+func teamWeekStat(weeklyStats *models.WeeklyStats, normTeam string) (models.TeamStat, bool) {
+	for _, teamStat := range weeklyStats.TeamStats {
+		if parser.NormalizeTeamName(teamStat.TeamName) == normTeam {
+			return teamStat, true
+		}
+	}
+
+	var teamStat models.TeamStat
+	var ppdTotal, mprTotal, weightTotal float64
+	found := false
+	for _, player := range weeklyStats.PlayerStats {
+		if parser.NormalizeTeamName(player.Team) != normTeam {
+			continue
+		}
+		found = true
+		teamStat.TeamName = player.Team
+		teamStat.GamesPlayed += player.GamesPlayed
+		teamStat.GamesWon += player.GamesWon
+		teamStat.DartsThrown += player.DartsThrown
+		weight := playerWeight(player)
+		ppdTotal += player.PPD * weight
+		mprTotal += player.MPR * weight
+		weightTotal += weight
+	}
+
+	if weightTotal > 0 {
+		teamStat.PPD = ppdTotal / weightTotal
+		teamStat.MPR = mprTotal / weightTotal
+	}
+	return teamStat, found
+}
+
+// meanStdDev returns the mean and population standard deviation of values
+func meanStdDev(values []float64) (float64, float64) {
+	if len(values) == 0 {
+		return 0, 0
+	}
+
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	mean := sum / float64(len(values))
+
+	var variance float64
+	for _, v := range values {
+		variance += (v - mean) * (v - mean)
+	}
+	return mean, math.Sqrt(variance / float64(len(values)))
+}
@@ -0,0 +1,90 @@
+package stats
+
+import (
+	"fmt"
+
+	"github.com/myusername/dart-statistic-scraper/pkg/models"
+)
+
+// ChangeType describes how a player differs from the previous run
+type ChangeType string
+
+// Change types reported by DiffWeeklyStats
+const (
+	ChangeNew     ChangeType = "new"
+	ChangeUpdated ChangeType = "changed"
+)
+
+// FieldChange holds the old and new value of a single stat
+type FieldChange struct {
+	Field string
+	Old   string
+	New   string
+}
+
+// PlayerChange describes a player whose stats differ from the previous run
+type PlayerChange struct {
+	Type    ChangeType
+	Player  models.PlayerStat
+	Changes []FieldChange // empty for new players
+}
+
+// DiffWeeklyStats returns the players in curr that are new or whose stats
+// changed compared to prev. A nil prev reports every player as new.
+func DiffWeeklyStats(prev, curr *models.WeeklyStats) []PlayerChange {
+	var changes []PlayerChange
+	if curr == nil {
+		return changes
+	}
+
+	previous := make(map[string]models.PlayerStat)
+	if prev != nil {
+		for _, player := range prev.PlayerStats {
+			previous[playerKey(player)] = player
+		}
+	}
+
+	for _, player := range curr.PlayerStats {
+		old, found := previous[playerKey(player)]
+		if !found {
+			changes = append(changes, PlayerChange{Type: ChangeNew, Player: player})
+			continue
+		}
+
+		if fields := diffPlayer(old, player); len(fields) > 0 {
+			changes = append(changes, PlayerChange{Type: ChangeUpdated, Player: player, Changes: fields})
+		}
+	}
+
+	return changes
+}
+
+// diffPlayer lists the stats that differ between two rows for the same player
+func diffPlayer(old, cur models.PlayerStat) []FieldChange {
+	var fields []FieldChange
+
+	addInt := func(name string, a, b int) {
+		if a != b {
+			fields = append(fields, FieldChange{Field: name, Old: fmt.Sprint(a), New: fmt.Sprint(b)})
+		}
+	}
+	addFloat := func(name string, a, b float64) {
+		// Values are published with two decimals, so compare at that precision
+		if fmt.Sprintf("%.2f", a) != fmt.Sprintf("%.2f", b) {
+			fields = append(fields, FieldChange{Field: name, Old: fmt.Sprintf("%.2f", a), New: fmt.Sprintf("%.2f", b)})
+		}
+	}
+
+	if old.SancPd != cur.SancPd {
+		fields = append(fields, FieldChange{Field: "SancPd", Old: old.SancPd, New: cur.SancPd})
+	}
+	addInt("Games", old.GamesPlayed, cur.GamesPlayed)
+	addInt("Wins", old.GamesWon, cur.GamesWon)
+	addFloat("PPD", old.PPD, cur.PPD)
+	addFloat("MPR", old.MPR, cur.MPR)
+	addInt("Hat", old.HatTricks, cur.HatTricks)
+	addInt("HstTon", old.HighScore, cur.HighScore)
+	addInt("HstOut", old.HighCheckout, cur.HighCheckout)
+
+	return fields
+}
@@ -0,0 +1,52 @@
+package stats
+
+import (
+	"sort"
+
+	"github.com/myusername/dart-statistic-scraper/pkg/models"
+)
+
+// DivisionPlayer is a player's season totals tagged with their division
+type DivisionPlayer struct {
+	Division string
+	models.PlayerSeasonStat
+}
+
+// SeasonTotals combines each player's weekly rows into season totals, keyed
+// internally by normalized name and team and sorted by that key
+func SeasonTotals(weeks []*models.WeeklyStats) []models.PlayerSeasonStat {
+	return aggregatePlayers(weeks)
+}
+
+// CompareDivisions merges the season totals of several divisions, keyed by
+// division name, into a single leaderboard of the top n players by metric (all
+// players when n <= 0). Players keep their division, so the same name in two
+// divisions stays two entries. Only players with at least MinQualifyingGames
+// games are included.
+func CompareDivisions(divisions map[string][]models.PlayerSeasonStat, metric string, n int) []DivisionPlayer {
+	var leaderboard []DivisionPlayer
+	for division, players := range divisions {
+		for _, player := range players {
+			if player.GamesPlayed < MinQualifyingGames {
+				continue
+			}
+			leaderboard = append(leaderboard, DivisionPlayer{Division: division, PlayerSeasonStat: player})
+		}
+	}
+
+	sort.Slice(leaderboard, func(i, j int) bool {
+		a, b := leaderboard[i], leaderboard[j]
+		if betterSeason(a.PlayerSeasonStat, b.PlayerSeasonStat, metric) {
+			return true
+		}
+		if betterSeason(b.PlayerSeasonStat, a.PlayerSeasonStat, metric) {
+			return false
+		}
+		return a.Division < b.Division
+	})
+
+	if n > 0 && len(leaderboard) > n {
+		leaderboard = leaderboard[:n]
+	}
+	return leaderboard
+}
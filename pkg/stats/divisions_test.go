@@ -0,0 +1,64 @@
+package stats
+
+import (
+	"testing"
+
+	"github.com/myusername/dart-statistic-scraper/pkg/models"
+)
+
+// divisionWeeks returns two weeks of stats with the same players
+func divisionWeeks(players ...models.PlayerStat) []*models.WeeklyStats {
+	var weeks []*models.WeeklyStats
+	for week := 1; week <= 2; week++ {
+		weeks = append(weeks, &models.WeeklyStats{Week: week, PlayerStats: players})
+	}
+	return weeks
+}
+
+func TestCompareDivisions(t *testing.T) {
+	// Both divisions have a JOHN SMITH on a HARBOR HILLS team
+	sun1 := divisionWeeks(
+		models.PlayerStat{PlayerName: "JOHN SMITH", Team: "HARBOR HILLS", GamesPlayed: 6, GamesWon: 4, PPD: 24.0, MPR: 2.5},
+		models.PlayerStat{PlayerName: "AMY LEE", Team: "REDHEADS", GamesPlayed: 6, GamesWon: 2, PPD: 18.0, MPR: 1.5},
+		models.PlayerStat{PlayerName: "RARE PLAYER", Team: "REDHEADS", GamesPlayed: 2, GamesWon: 2, PPD: 40.0, MPR: 4.0},
+	)
+	sun2 := divisionWeeks(
+		models.PlayerStat{PlayerName: "JOHN SMITH", Team: "HARBOR HILLS", GamesPlayed: 6, GamesWon: 5, PPD: 21.0, MPR: 3.0},
+		models.PlayerStat{PlayerName: "BOB JONES", Team: "BRIDGE INN 1", GamesPlayed: 6, GamesWon: 3, PPD: 22.0, MPR: 2.0},
+	)
+	divisions := map[string][]models.PlayerSeasonStat{
+		"SUN1": SeasonTotals(sun1),
+		"SUN2": SeasonTotals(sun2),
+	}
+
+	tests := []struct {
+		metric string
+		n      int
+		want   []DivisionPlayer
+	}{
+		{MetricPPD, 3, []DivisionPlayer{
+			{Division: "SUN1", PlayerSeasonStat: models.PlayerSeasonStat{PlayerName: "JOHN SMITH"}},
+			{Division: "SUN2", PlayerSeasonStat: models.PlayerSeasonStat{PlayerName: "BOB JONES"}},
+			{Division: "SUN2", PlayerSeasonStat: models.PlayerSeasonStat{PlayerName: "JOHN SMITH"}},
+		}},
+		{MetricMPR, 0, []DivisionPlayer{
+			{Division: "SUN2", PlayerSeasonStat: models.PlayerSeasonStat{PlayerName: "JOHN SMITH"}},
+			{Division: "SUN1", PlayerSeasonStat: models.PlayerSeasonStat{PlayerName: "JOHN SMITH"}},
+			{Division: "SUN2", PlayerSeasonStat: models.PlayerSeasonStat{PlayerName: "BOB JONES"}},
+			{Division: "SUN1", PlayerSeasonStat: models.PlayerSeasonStat{PlayerName: "AMY LEE"}},
+		}},
+	}
+
+	for _, tt := range tests {
+		got := CompareDivisions(divisions, tt.metric, tt.n)
+		if len(got) != len(tt.want) {
+			t.Fatalf("CompareDivisions(%s, %d) returned %d players, want %d: %+v", tt.metric, tt.n, len(got), len(tt.want), got)
+		}
+		for i := range got {
+			if got[i].Division != tt.want[i].Division || got[i].PlayerName != tt.want[i].PlayerName {
+				t.Errorf("CompareDivisions(%s, %d)[%d] = %s/%s, want %s/%s", tt.metric, tt.n, i,
+					got[i].Division, got[i].PlayerName, tt.want[i].Division, tt.want[i].PlayerName)
+			}
+		}
+	}
+}
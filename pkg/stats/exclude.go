@@ -0,0 +1,86 @@
+package stats
+
+import (
+	"github.com/myusername/dart-statistic-scraper/pkg/models"
+	"github.com/myusername/dart-statistic-scraper/pkg/parser"
+)
+
+// Exclusions lists teams and players, by normalized name, that are dropped
+// from all outputs and aggregates. The zero value excludes nothing.
+type Exclusions struct {
+	teams   map[string]bool
+	players map[string]bool
+}
+
+// NewExclusions builds an exclusion list from team and player names
+func NewExclusions(teams, players []string) Exclusions {
+	e := Exclusions{
+		teams:   make(map[string]bool),
+		players: make(map[string]bool),
+	}
+	for _, team := range teams {
+		if normTeam := parser.NormalizeTeamName(team); normTeam != "" {
+			e.teams[normTeam] = true
+		}
+	}
+	for _, player := range players {
+		if normPlayer := normalizePlayerName(player); normPlayer != "" {
+			e.players[normPlayer] = true
+		}
+	}
+	return e
+}
+
+// Empty reports whether nothing is excluded
+func (e Exclusions) Empty() bool {
+	return len(e.teams) == 0 && len(e.players) == 0
+}
+
+// ExcludesTeam reports whether a team is excluded
+func (e Exclusions) ExcludesTeam(team string) bool {
+	return e.teams[parser.NormalizeTeamName(team)]
+}
+
+// ExcludesPlayer reports whether a player is excluded, either by name or
+// because their team is excluded
+func (e Exclusions) ExcludesPlayer(player models.PlayerStat) bool {
+	return e.players[normalizePlayerName(player.PlayerName)] || e.ExcludesTeam(player.Team)
+}
+
+// FilterWeeklyStats returns a copy of the week without excluded players and teams
+func (e Exclusions) FilterWeeklyStats(ws *models.WeeklyStats) *models.WeeklyStats {
+	if ws == nil || e.Empty() {
+		return ws
+	}
+
+	filtered := *ws
+	filtered.PlayerStats = nil
+	for _, player := range ws.PlayerStats {
+		if !e.ExcludesPlayer(player) {
+			filtered.PlayerStats = append(filtered.PlayerStats, player)
+		}
+	}
+
+	filtered.TeamStats = nil
+	for _, team := range ws.TeamStats {
+		if !e.ExcludesTeam(team.TeamName) {
+			filtered.TeamStats = append(filtered.TeamStats, team)
+		}
+	}
+	return &filtered
+}
+
+// FilterSchedules drops matches involving an excluded team
+func (e Exclusions) FilterSchedules(schedules []models.MatchSchedule) []models.MatchSchedule {
+	if len(e.teams) == 0 {
+		return schedules
+	}
+
+	var filtered []models.MatchSchedule
+	for _, match := range schedules {
+		if !e.ExcludesTeam(match.HomeTeam) && !e.ExcludesTeam(match.AwayTeam) {
+			filtered = append(filtered, match)
+		}
+	}
+	return filtered
+}
@@ -0,0 +1,78 @@
+package stats
+
+import (
+	"sort"
+
+	"github.com/myusername/dart-statistic-scraper/pkg/models"
+)
+
+// FeatType identifies a kind of notable single-game performance
+type FeatType string
+
+// Supported feats
+const (
+	// FeatMaximum is a 180, the highest possible three-dart score
+	FeatMaximum FeatType = "180"
+	// FeatNineDartRange means the player hit a 180 and a checkout of at least
+	// NineDartCheckout in the same week, the ingredients of a nine-dart leg
+	// (e.g. 180, 180, 141). It's an indicator, not proof, that one was thrown.
+	FeatNineDartRange FeatType = "nine-dart-range"
+	// FeatHighCheckout is a checkout of at least HighCheckoutThreshold
+	FeatHighCheckout FeatType = "high-checkout"
+)
+
+// HighCheckoutThreshold is the lowest checkout reported as a FeatHighCheckout
+var HighCheckoutThreshold = 120
+
+// NineDartCheckout is the lowest checkout that can finish a nine-dart leg after two 180s
+const NineDartCheckout = 141
+
+// Feat is a notable single-game performance by a player
+type Feat struct {
+	Type   FeatType
+	Week   int
+	Player string
+	Team   string
+	Value  int
+}
+
+// NotableFeats flags the players in a week who hit a 180, have a nine-dart
+// range indicator, or checked out at least HighCheckoutThreshold. A player can
+// have several feats. Results are sorted by type, then value (highest first),
+// then player name.
+func NotableFeats(weeklyStats *models.WeeklyStats) []Feat {
+	if weeklyStats == nil {
+		return nil
+	}
+
+	var feats []Feat
+	for _, player := range weeklyStats.PlayerStats {
+		feat := Feat{Week: weeklyStats.Week, Player: player.PlayerName, Team: player.Team}
+
+		if player.HighScore == 180 {
+			feat.Type, feat.Value = FeatMaximum, player.HighScore
+			feats = append(feats, feat)
+
+			if player.HighCheckout >= NineDartCheckout {
+				feat.Type, feat.Value = FeatNineDartRange, player.HighCheckout
+				feats = append(feats, feat)
+			}
+		}
+
+		if HighCheckoutThreshold > 0 && player.HighCheckout >= HighCheckoutThreshold {
+			feat.Type, feat.Value = FeatHighCheckout, player.HighCheckout
+			feats = append(feats, feat)
+		}
+	}
+
+	sort.SliceStable(feats, func(i, j int) bool {
+		if feats[i].Type != feats[j].Type {
+			return feats[i].Type < feats[j].Type
+		}
+		if feats[i].Value != feats[j].Value {
+			return feats[i].Value > feats[j].Value
+		}
+		return feats[i].Player < feats[j].Player
+	})
+	return feats
+}
@@ -0,0 +1,79 @@
+package stats
+
+import (
+	"strings"
+
+	"github.com/myusername/dart-statistic-scraper/pkg/models"
+	"github.com/myusername/dart-statistic-scraper/pkg/parser"
+)
+
+// H2HRecord is how two teams fared in the weeks they played each other
+type H2HRecord struct {
+	TeamA string `json:"teamA"`
+	TeamB string `json:"teamB"`
+	// Weeks lists the weeks the teams met, in the order they were found
+	Weeks []int `json:"weeks"`
+	// WinsA and WinsB are the games each team won in those weeks
+	WinsA int `json:"winsA"`
+	WinsB int `json:"winsB"`
+}
+
+// HeadToHead totals the games teamA and teamB won in the weeks the schedule
+// has them playing each other. Team names are matched after normalization,
+// and weeks either team's stats are missing from are skipped.
+func HeadToHead(teamA, teamB string, weeks []*models.WeeklyStats, schedules []models.MatchSchedule) H2HRecord {
+	record := H2HRecord{TeamA: teamA, TeamB: teamB}
+	normA := parser.NormalizeTeamName(teamA)
+	normB := parser.NormalizeTeamName(teamB)
+
+	counted := make(map[int]bool)
+	for _, weeklyStats := range weeks {
+		if weeklyStats == nil || counted[weeklyStats.Week] {
+			continue
+		}
+
+		opponent, scheduled := scheduledOpponent(normA, weeklyStats.Week, schedules)
+		if !scheduled || parser.NormalizeTeamName(opponent) != normB {
+			continue
+		}
+
+		statsA, foundA := teamWeekStat(weeklyStats, normA)
+		statsB, foundB := teamWeekStat(weeklyStats, normB)
+		if !foundA || !foundB {
+			continue
+		}
+
+		counted[weeklyStats.Week] = true
+		record.Weeks = append(record.Weeks, weeklyStats.Week)
+		record.WinsA += statsA.GamesWon
+		record.WinsB += statsB.GamesWon
+	}
+
+	return record
+}
+
+// scheduledOpponent returns the team normTeam is scheduled to play in a week.
+// Unlike parser.FindOpponent it always names the opposing team, never the
+// sub-match pairing, and reports false for a BYE or a week without a match.
+func scheduledOpponent(normTeam string, week int, schedules []models.MatchSchedule) (string, bool) {
+	for _, schedule := range schedules {
+		if schedule.Week != week {
+			continue
+		}
+
+		var opponent string
+		switch normTeam {
+		case parser.NormalizeTeamName(schedule.HomeTeam):
+			opponent = schedule.AwayTeam
+		case parser.NormalizeTeamName(schedule.AwayTeam):
+			opponent = schedule.HomeTeam
+		default:
+			continue
+		}
+		if strings.EqualFold(strings.TrimSpace(opponent), parser.ByeTeam) {
+			return "", false
+		}
+		return opponent, true
+	}
+	return "", false
+}
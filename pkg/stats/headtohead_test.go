@@ -0,0 +1,45 @@
+package stats
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/myusername/dart-statistic-scraper/pkg/models"
+)
+
+// teamWeek returns a week's stats with the games won by each team
+func teamWeek(week int, gamesWon map[string]int) *models.WeeklyStats {
+	ws := &models.WeeklyStats{Week: week}
+	for team, won := range gamesWon {
+		ws.TeamStats = append(ws.TeamStats, models.TeamStat{TeamName: team, GamesPlayed: 21, GamesWon: won})
+	}
+	return ws
+}
+
+func TestHeadToHead(t *testing.T) {
+	weeks := []*models.WeeklyStats{
+		teamWeek(1, map[string]int{"HARBOR HILLS": 12, "REDHEADS": 9}),
+		teamWeek(2, map[string]int{"HARBOR HILLS": 10, "BRIDGE INN 1": 11}),
+		teamWeek(3, map[string]int{"HARBOR HILLS": 8, "REDHEADS": 13}),
+		teamWeek(4, map[string]int{"HARBOR HILLS": 0}),
+	}
+	schedules := []models.MatchSchedule{
+		{Week: 1, HomeTeam: "HARBOR HILLS", AwayTeam: "REDHEADS"},
+		{Week: 2, HomeTeam: "BRIDGE INN 1", AwayTeam: "HARBOR HILLS"},
+		// The second meeting names the sub-match pairings
+		{Week: 3, HomeTeam: "REDHEADS", AwayTeam: "HARBOR HILLS", HomeSubMatch: "Z/W", AwaySubMatch: "X/Y"},
+		{Week: 4, HomeTeam: "HARBOR HILLS", AwayTeam: "BYE"},
+	}
+
+	got := HeadToHead("Harbor Hills", "Redheads", weeks, schedules)
+	want := H2HRecord{TeamA: "Harbor Hills", TeamB: "Redheads", Weeks: []int{1, 3}, WinsA: 20, WinsB: 22}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("HeadToHead() = %+v, want %+v", got, want)
+	}
+
+	// Teams that never met have an empty record
+	got = HeadToHead("REDHEADS", "BRIDGE INN 1", weeks, schedules)
+	if len(got.Weeks) != 0 || got.WinsA != 0 || got.WinsB != 0 {
+		t.Errorf("HeadToHead() of teams that never met = %+v", got)
+	}
+}
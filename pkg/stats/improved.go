@@ -0,0 +1,67 @@
+package stats
+
+import (
+	"sort"
+
+	"github.com/myusername/dart-statistic-scraper/pkg/models"
+)
+
+// MinImprovementGames is the number of games a player needs in both weeks to
+// be considered by MostImproved
+var MinImprovementGames = 3
+
+// ImprovementEntry is a player's change in PPD from one week to the next
+type ImprovementEntry struct {
+	Player     string  `json:"player"`
+	Team       string  `json:"team"`
+	PriorPPD   float64 `json:"priorPpd"`
+	CurrentPPD float64 `json:"currentPpd"`
+	Delta      float64 `json:"delta"`
+}
+
+// MostImproved returns the n players whose PPD rose the most from prev to curr
+// (all improving players when n <= 0), largest gain first. Only players on the
+// same team in both weeks with at least MinImprovementGames games each count.
+func MostImproved(prev, curr *models.WeeklyStats, n int) []ImprovementEntry {
+	if prev == nil || curr == nil {
+		return nil
+	}
+
+	prior := make(map[string]models.PlayerStat)
+	for _, player := range prev.PlayerStats {
+		if player.GamesPlayed >= MinImprovementGames {
+			prior[playerKey(player)] = player
+		}
+	}
+
+	var entries []ImprovementEntry
+	for _, player := range curr.PlayerStats {
+		before, found := prior[playerKey(player)]
+		if !found || player.GamesPlayed < MinImprovementGames {
+			continue
+		}
+
+		delta := player.PPD - before.PPD
+		if delta > 0 {
+			entries = append(entries, ImprovementEntry{
+				Player:     player.PlayerName,
+				Team:       player.Team,
+				PriorPPD:   before.PPD,
+				CurrentPPD: player.PPD,
+				Delta:      delta,
+			})
+		}
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].Delta != entries[j].Delta {
+			return entries[i].Delta > entries[j].Delta
+		}
+		return entries[i].Player < entries[j].Player
+	})
+
+	if n > 0 && len(entries) > n {
+		entries = entries[:n]
+	}
+	return entries
+}
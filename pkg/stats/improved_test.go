@@ -0,0 +1,44 @@
+package stats
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/myusername/dart-statistic-scraper/pkg/models"
+)
+
+func TestMostImproved(t *testing.T) {
+	prev := &models.WeeklyStats{Week: 4, PlayerStats: []models.PlayerStat{
+		{PlayerName: "JOHN SMITH", Team: "HARBOR HILLS", GamesPlayed: 10, PPD: 20},
+		{PlayerName: "MARY JONES", Team: "REDHEADS", GamesPlayed: 8, PPD: 18},
+		{PlayerName: "TOM NG", Team: "REDHEADS", GamesPlayed: 9, PPD: 30},
+		{PlayerName: "AMY LEE", Team: "REDHEADS", GamesPlayed: 2, PPD: 10},
+		{PlayerName: "BOB RAY", Team: "THE HUTCH", GamesPlayed: 9, PPD: 15},
+	}}
+	curr := &models.WeeklyStats{Week: 5, PlayerStats: []models.PlayerStat{
+		{PlayerName: "JOHN SMITH", Team: "HARBOR HILLS", GamesPlayed: 10, PPD: 25},
+		{PlayerName: "MARY JONES", Team: "REDHEADS", GamesPlayed: 6, PPD: 20},
+		// A drop in PPD isn't an improvement
+		{PlayerName: "TOM NG", Team: "REDHEADS", GamesPlayed: 9, PPD: 28},
+		// Too few games the week before
+		{PlayerName: "AMY LEE", Team: "REDHEADS", GamesPlayed: 9, PPD: 30},
+		// A different team is a different player
+		{PlayerName: "BOB RAY", Team: "GRAND AVE", GamesPlayed: 9, PPD: 35},
+		// Not in the earlier week
+		{PlayerName: "CAL DOE", Team: "THE HUTCH", GamesPlayed: 9, PPD: 40},
+	}}
+
+	want := []ImprovementEntry{
+		{Player: "JOHN SMITH", Team: "HARBOR HILLS", PriorPPD: 20, CurrentPPD: 25, Delta: 5},
+		{Player: "MARY JONES", Team: "REDHEADS", PriorPPD: 18, CurrentPPD: 20, Delta: 2},
+	}
+	if got := MostImproved(prev, curr, 0); !reflect.DeepEqual(got, want) {
+		t.Errorf("MostImproved() = %+v, want %+v", got, want)
+	}
+	if got := MostImproved(prev, curr, 1); !reflect.DeepEqual(got, want[:1]) {
+		t.Errorf("MostImproved(n=1) = %+v, want %+v", got, want[:1])
+	}
+	if got := MostImproved(nil, curr, 0); got != nil {
+		t.Errorf("MostImproved() without an earlier week = %+v, want nil", got)
+	}
+}
@@ -0,0 +1,79 @@
+package stats
+
+import (
+	"sort"
+
+	"github.com/myusername/dart-statistic-scraper/pkg/models"
+)
+
+// TopPlayersByPPD returns the n players with the highest PPD (all players when
+// n <= 0), leaving out players who played no games. Ties are broken by games
+// won and then by name.
+func TopPlayersByPPD(players []models.PlayerStat, n int) []models.PlayerStat {
+	return topPlayers(players, n, func(player models.PlayerStat) float64 { return player.PPD })
+}
+
+// TopPlayersByMPR returns the n players with the highest MPR, like TopPlayersByPPD
+func TopPlayersByMPR(players []models.PlayerStat, n int) []models.PlayerStat {
+	return topPlayers(players, n, func(player models.PlayerStat) float64 { return player.MPR })
+}
+
+// topPlayers ranks the players who played by value, best first
+func topPlayers(players []models.PlayerStat, n int, value func(models.PlayerStat) float64) []models.PlayerStat {
+	var ranked []models.PlayerStat
+	for _, player := range players {
+		if player.GamesPlayed > 0 {
+			ranked = append(ranked, player)
+		}
+	}
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		vi, vj := value(ranked[i]), value(ranked[j])
+		if vi != vj {
+			return vi > vj
+		}
+		if ranked[i].GamesWon != ranked[j].GamesWon {
+			return ranked[i].GamesWon > ranked[j].GamesWon
+		}
+		return ranked[i].PlayerName < ranked[j].PlayerName
+	})
+
+	if n > 0 && len(ranked) > n {
+		ranked = ranked[:n]
+	}
+	return ranked
+}
+
+// TopCheckouts returns the n players with the highest HighCheckout (all when
+// n <= 0), leaving out players without one. Ties are broken by name.
+func TopCheckouts(players []models.PlayerStat, n int) []models.PlayerStat {
+	return topHighMarks(players, n, func(player models.PlayerStat) int { return player.HighCheckout })
+}
+
+// TopScores returns the n players with the highest HighScore, like TopCheckouts
+func TopScores(players []models.PlayerStat, n int) []models.PlayerStat {
+	return topHighMarks(players, n, func(player models.PlayerStat) int { return player.HighScore })
+}
+
+// topHighMarks ranks the players with a non-zero value, highest first
+func topHighMarks(players []models.PlayerStat, n int, value func(models.PlayerStat) int) []models.PlayerStat {
+	var ranked []models.PlayerStat
+	for _, player := range players {
+		if value(player) > 0 {
+			ranked = append(ranked, player)
+		}
+	}
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		vi, vj := value(ranked[i]), value(ranked[j])
+		if vi != vj {
+			return vi > vj
+		}
+		return ranked[i].PlayerName < ranked[j].PlayerName
+	})
+
+	if n > 0 && len(ranked) > n {
+		ranked = ranked[:n]
+	}
+	return ranked
+}
@@ -0,0 +1,77 @@
+package stats
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/myusername/dart-statistic-scraper/pkg/models"
+)
+
+// playerNames returns the names of players in order
+func playerNames(players []models.PlayerStat) []string {
+	var names []string
+	for _, player := range players {
+		names = append(names, player.PlayerName)
+	}
+	return names
+}
+
+func TestTopPlayersByPPD(t *testing.T) {
+	players := []models.PlayerStat{
+		{PlayerName: "ZED", GamesPlayed: 10, GamesWon: 5, PPD: 25, MPR: 2.0},
+		{PlayerName: "AMY", GamesPlayed: 10, GamesWon: 5, PPD: 25, MPR: 3.0},
+		{PlayerName: "BOB", GamesPlayed: 10, GamesWon: 7, PPD: 25, MPR: 1.0},
+		{PlayerName: "CAL", GamesPlayed: 10, GamesWon: 2, PPD: 30, MPR: 2.0},
+		{PlayerName: "DAN", GamesPlayed: 0, GamesWon: 0, PPD: 40, MPR: 4.0},
+	}
+
+	tests := []struct {
+		name string
+		got  []models.PlayerStat
+		want []string
+	}{
+		// Ties on PPD go to more wins, then to the name
+		{"all by PPD", TopPlayersByPPD(players, 0), []string{"CAL", "BOB", "AMY", "ZED"}},
+		{"top 2 by PPD", TopPlayersByPPD(players, 2), []string{"CAL", "BOB"}},
+		{"more than there are", TopPlayersByPPD(players, 10), []string{"CAL", "BOB", "AMY", "ZED"}},
+		{"all by MPR", TopPlayersByMPR(players, -1), []string{"AMY", "ZED", "CAL", "BOB"}},
+	}
+	for _, tt := range tests {
+		if got := playerNames(tt.got); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+
+	// Ranking leaves the input alone
+	if players[0].PlayerName != "ZED" {
+		t.Errorf("TopPlayersByPPD reordered its input: %v", playerNames(players))
+	}
+}
+
+func TestTopCheckoutsAndScores(t *testing.T) {
+	players := []models.PlayerStat{
+		{PlayerName: "ZED", HighScore: 140, HighCheckout: 0},
+		{PlayerName: "AMY", HighScore: 0, HighCheckout: 96},
+		{PlayerName: "BOB", HighScore: 140, HighCheckout: 120},
+		{PlayerName: "CAL", HighScore: 180, HighCheckout: 96},
+		{PlayerName: "DAN"},
+	}
+
+	tests := []struct {
+		name string
+		got  []models.PlayerStat
+		want []string
+	}{
+		// Players without a high mark are left out; ties go to the name
+		{"all checkouts", TopCheckouts(players, 0), []string{"BOB", "AMY", "CAL"}},
+		{"top checkout", TopCheckouts(players, 1), []string{"BOB"}},
+		{"all scores", TopScores(players, 0), []string{"CAL", "BOB", "ZED"}},
+		{"top 2 scores", TopScores(players, 2), []string{"CAL", "BOB"}},
+		{"no high marks", TopScores([]models.PlayerStat{{PlayerName: "DAN"}}, 3), nil},
+	}
+	for _, tt := range tests {
+		if got := playerNames(tt.got); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
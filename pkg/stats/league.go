@@ -0,0 +1,53 @@
+package stats
+
+import (
+	"github.com/myusername/dart-statistic-scraper/pkg/models"
+	"github.com/myusername/dart-statistic-scraper/pkg/parser"
+)
+
+// LeagueWeekSummary holds league-wide totals for a single week
+type LeagueWeekSummary struct {
+	Week          int
+	Date          string
+	Teams         int
+	ActivePlayers int
+	TotalGames    int
+	AvgPPD        float64
+	AvgMPR        float64
+}
+
+// LeagueWeeklyTotals computes one summary per week across all teams, sorted by
+// week. Only players who played at least one game count as active, and the
+// averages use AverageWeighting.
+func LeagueWeeklyTotals(weeks []*models.WeeklyStats) []LeagueWeekSummary {
+	var summaries []LeagueWeekSummary
+	for _, weeklyStats := range sortedWeeks(weeks) {
+		summary := LeagueWeekSummary{
+			Week: weeklyStats.Week,
+			Date: weeklyStats.Date,
+		}
+
+		teams := make(map[string]bool)
+		var ppdTotal, mprTotal, weightTotal float64
+		for _, player := range weeklyStats.PlayerStats {
+			if player.GamesPlayed == 0 {
+				continue
+			}
+			teams[parser.NormalizeTeamName(player.Team)] = true
+			summary.ActivePlayers++
+			summary.TotalGames += player.GamesPlayed
+			weight := playerWeight(player)
+			ppdTotal += player.PPD * weight
+			mprTotal += player.MPR * weight
+			weightTotal += weight
+		}
+
+		summary.Teams = len(teams)
+		if weightTotal > 0 {
+			summary.AvgPPD = ppdTotal / weightTotal
+			summary.AvgMPR = mprTotal / weightTotal
+		}
+		summaries = append(summaries, summary)
+	}
+	return summaries
+}
@@ -0,0 +1,160 @@
+package stats
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/myusername/dart-statistic-scraper/pkg/models"
+	"github.com/myusername/dart-statistic-scraper/pkg/parser"
+)
+
+// Model parameters used by PredictMatchups
+const (
+	// MPRToPPDFactor converts MPR into a PPD-equivalent so both games count
+	// equally; a 2.5 MPR cricket player is roughly a 25 PPD X01 player
+	MPRToPPDFactor = 10.0
+
+	// PredictionScale is the strength difference (in PPD) that gives the
+	// stronger team roughly a 73% chance of winning
+	PredictionScale = 3.0
+)
+
+// Prediction is the expected outcome of a scheduled match
+type Prediction struct {
+	Week               int
+	HomeTeam           string
+	AwayTeam           string
+	HomeStrength       float64
+	AwayStrength       float64
+	HomeWinProbability float64
+	Favorite           string
+}
+
+// PredictMatchups estimates the winner of each match scheduled in week.
+//
+// Each team's strength is the average of its season PPD and its season MPR
+// scaled by MPRToPPDFactor, using weighted totals from the weeks before
+// week. The home team's win probability is a logistic curve on the strength
+// difference:
+//
+//	P(home) = 1 / (1 + exp(-(home - away) / PredictionScale))
+//
+// Teams without any earlier results get a neutral 50% chance. BYE weeks are skipped.
+func PredictMatchups(weeks []*models.WeeklyStats, schedules []models.MatchSchedule, week int) []Prediction {
+	var predictions []Prediction
+
+	var history []*models.WeeklyStats
+	for _, weeklyStats := range weeks {
+		if weeklyStats != nil && weeklyStats.Week < week {
+			history = append(history, weeklyStats)
+		}
+	}
+	totals := teamSeasonTotals(history)
+
+	seen := make(map[string]bool)
+	for _, schedule := range schedules {
+		if schedule.Week != week ||
+			strings.EqualFold(schedule.HomeTeam, "BYE") || strings.EqualFold(schedule.AwayTeam, "BYE") {
+			continue
+		}
+
+		home := parser.NormalizeTeamName(schedule.HomeTeam)
+		away := parser.NormalizeTeamName(schedule.AwayTeam)
+		key := matchupKey(week, home, away)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		prediction := Prediction{
+			Week:               week,
+			HomeTeam:           schedule.HomeTeam,
+			AwayTeam:           schedule.AwayTeam,
+			HomeWinProbability: 0.5,
+		}
+
+		homeStat, homeFound := totals[home]
+		awayStat, awayFound := totals[away]
+		if homeFound && awayFound {
+			prediction.HomeStrength = teamStrength(homeStat)
+			prediction.AwayStrength = teamStrength(awayStat)
+			diff := prediction.HomeStrength - prediction.AwayStrength
+			prediction.HomeWinProbability = 1 / (1 + math.Exp(-diff/PredictionScale))
+		}
+
+		switch {
+		case prediction.HomeWinProbability > 0.5:
+			prediction.Favorite = schedule.HomeTeam
+		case prediction.HomeWinProbability < 0.5:
+			prediction.Favorite = schedule.AwayTeam
+		}
+
+		predictions = append(predictions, prediction)
+	}
+
+	return predictions
+}
+
+// teamStrength combines a team's PPD and MPR into a single PPD-equivalent number
+func teamStrength(teamStat models.TeamStat) float64 {
+	return (teamStat.PPD + teamStat.MPR*MPRToPPDFactor) / 2
+}
+
+// teamSeasonTotals returns each team's totals across weeks, averaged using
+// AverageWeighting and keyed by normalized team name
+func teamSeasonTotals(weeks []*models.WeeklyStats) map[string]models.TeamStat {
+	totals := make(map[string]models.TeamStat)
+	ppdTotals := make(map[string]float64)
+	mprTotals := make(map[string]float64)
+	weightTotals := make(map[string]float64)
+
+	for _, weeklyStats := range weeks {
+		teams := make(map[string]bool)
+		for _, player := range weeklyStats.PlayerStats {
+			teams[parser.NormalizeTeamName(player.Team)] = true
+		}
+		for _, teamStat := range weeklyStats.TeamStats {
+			teams[parser.NormalizeTeamName(teamStat.TeamName)] = true
+		}
+
+		for team := range teams {
+			if team == "" {
+				continue
+			}
+			weekStat, found := teamWeekStat(weeklyStats, team)
+			if !found {
+				continue
+			}
+			total := totals[team]
+			if total.TeamName == "" {
+				total.TeamName = weekStat.TeamName
+			}
+			total.GamesPlayed += weekStat.GamesPlayed
+			total.GamesWon += weekStat.GamesWon
+			total.DartsThrown += weekStat.DartsThrown
+			weight := teamWeight(weekStat)
+			ppdTotals[team] += weekStat.PPD * weight
+			mprTotals[team] += weekStat.MPR * weight
+			weightTotals[team] += weight
+			totals[team] = total
+		}
+	}
+
+	for team, total := range totals {
+		if weightTotals[team] > 0 {
+			total.PPD = ppdTotals[team] / weightTotals[team]
+			total.MPR = mprTotals[team] / weightTotals[team]
+		}
+		totals[team] = total
+	}
+	return totals
+}
+
+// matchupKey identifies a pairing in a week regardless of home/away order
+func matchupKey(week int, teamA, teamB string) string {
+	if teamB < teamA {
+		teamA, teamB = teamB, teamA
+	}
+	return fmt.Sprintf("%d|%s|%s", week, teamA, teamB)
+}
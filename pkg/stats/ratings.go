@@ -0,0 +1,114 @@
+package stats
+
+import (
+	"math"
+	"sort"
+
+	"github.com/myusername/dart-statistic-scraper/pkg/models"
+	"github.com/myusername/dart-statistic-scraper/pkg/parser"
+)
+
+// Elo parameters used by ComputeRatings
+const (
+	// EloInitialRating is the rating every player starts the season with
+	EloInitialRating = 1500.0
+
+	// EloKFactor is the largest change a single week can make to a rating.
+	// 32 is the classic value for players with few rated results, which
+	// suits a season of at most a few dozen weeks.
+	EloKFactor = 32.0
+)
+
+// ComputeRatings estimates an Elo-style rating for every player over the season.
+//
+// Weeks are processed in order. Each player starts at EloInitialRating, and in
+// every week they played, their rating moves by EloKFactor * (actual - expected):
+//   - actual is the player's win fraction for the week (GamesWon / GamesPlayed)
+//   - expected is the standard Elo expectation against the opponent rating
+//   - the opponent rating is the average rating of the opposing team's players
+//     going into the week, or EloInitialRating if none of them are rated yet
+//
+// Weeks where the opponent can't be found in the schedule (or is a BYE) leave
+// the rating unchanged. The result is keyed by normalized player name.
+func ComputeRatings(weeks []*models.WeeklyStats, schedules []models.MatchSchedule) map[string]float64 {
+	ratings := make(map[string]float64)
+
+	for _, weeklyStats := range sortedWeeks(weeks) {
+		// Snapshot team strength before any of this week's updates
+		teamRatings := teamAverageRatings(weeklyStats.PlayerStats, ratings)
+
+		updated := make(map[string]float64)
+		for _, player := range weeklyStats.PlayerStats {
+			name := normalizePlayerName(player.PlayerName)
+			if name == "" {
+				continue
+			}
+
+			rating, rated := ratings[name]
+			if !rated {
+				rating = EloInitialRating
+				ratings[name] = rating
+			}
+
+			if player.GamesPlayed <= 0 {
+				continue
+			}
+
+			opponent, scheduled := scheduledOpponent(parser.NormalizeTeamName(player.Team), weeklyStats.Week, schedules)
+			if !scheduled {
+				continue
+			}
+
+			opponentRating, found := teamRatings[parser.NormalizeTeamName(opponent)]
+			if !found {
+				opponentRating = EloInitialRating
+			}
+
+			actual := float64(player.GamesWon) / float64(player.GamesPlayed)
+			expected := 1 / (1 + math.Pow(10, (opponentRating-rating)/400))
+			updated[name] = rating + EloKFactor*(actual-expected)
+		}
+
+		for name, rating := range updated {
+			ratings[name] = rating
+		}
+	}
+
+	return ratings
+}
+
+// teamAverageRatings returns the average current rating of each team's players
+func teamAverageRatings(players []models.PlayerStat, ratings map[string]float64) map[string]float64 {
+	totals := make(map[string]float64)
+	counts := make(map[string]int)
+
+	for _, player := range players {
+		rating, rated := ratings[normalizePlayerName(player.PlayerName)]
+		if !rated {
+			continue
+		}
+		team := parser.NormalizeTeamName(player.Team)
+		totals[team] += rating
+		counts[team]++
+	}
+
+	averages := make(map[string]float64)
+	for team, total := range totals {
+		averages[team] = total / float64(counts[team])
+	}
+	return averages
+}
+
+// sortedWeeks returns the non-nil weeks ordered by week number
+func sortedWeeks(weeks []*models.WeeklyStats) []*models.WeeklyStats {
+	var sorted []*models.WeeklyStats
+	for _, weeklyStats := range weeks {
+		if weeklyStats != nil {
+			sorted = append(sorted, weeklyStats)
+		}
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Week < sorted[j].Week
+	})
+	return sorted
+}
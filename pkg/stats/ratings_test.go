@@ -0,0 +1,56 @@
+package stats
+
+import (
+	"math"
+	"testing"
+
+	"github.com/myusername/dart-statistic-scraper/pkg/models"
+)
+
+func TestComputeRatingsUsesOpposingTeam(t *testing.T) {
+	weeks := []*models.WeeklyStats{
+		{Week: 1, PlayerStats: []models.PlayerStat{
+			{PlayerName: "JOHN SMITH", Team: "HARBOR HILLS", GamesPlayed: 10, GamesWon: 10},
+			{PlayerName: "MARY JONES", Team: "REDHEADS", GamesPlayed: 10, GamesWon: 0},
+		}},
+		{Week: 2, PlayerStats: []models.PlayerStat{
+			{PlayerName: "JOHN SMITH", Team: "HARBOR HILLS", GamesPlayed: 10, GamesWon: 5},
+			{PlayerName: "MARY JONES", Team: "REDHEADS", GamesPlayed: 10, GamesWon: 5},
+		}},
+	}
+	schedules := []models.MatchSchedule{
+		{Week: 1, HomeTeam: "HARBOR HILLS", AwayTeam: "REDHEADS"},
+		// Sub-match pairings must not hide the opposing team's rating
+		{Week: 2, HomeTeam: "REDHEADS", AwayTeam: "HARBOR HILLS", HomeSubMatch: "Z/W", AwaySubMatch: "X/Y"},
+	}
+
+	// Week 1: nobody is rated yet, so both face EloInitialRating
+	john := EloInitialRating + EloKFactor*(1-0.5)
+	mary := EloInitialRating + EloKFactor*(0-0.5)
+
+	// Week 2: each faces the other team's week 1 rating
+	expected := func(rating, opponent float64) float64 {
+		return 1 / (1 + math.Pow(10, (opponent-rating)/400))
+	}
+	john, mary = john+EloKFactor*(0.5-expected(john, mary)), mary+EloKFactor*(0.5-expected(mary, john))
+
+	ratings := ComputeRatings(weeks, schedules)
+	for name, want := range map[string]float64{"JOHN SMITH": john, "MARY JONES": mary} {
+		if got := ratings[name]; math.Abs(got-want) > 1e-9 {
+			t.Errorf("rating of %s = %v, want %v", name, got, want)
+		}
+	}
+}
+
+func TestComputeRatingsSkipsByeWeeks(t *testing.T) {
+	weeks := []*models.WeeklyStats{
+		{Week: 1, PlayerStats: []models.PlayerStat{
+			{PlayerName: "JOHN SMITH", Team: "HARBOR HILLS", GamesPlayed: 10, GamesWon: 10},
+		}},
+	}
+	schedules := []models.MatchSchedule{{Week: 1, HomeTeam: "HARBOR HILLS", AwayTeam: "BYE"}}
+
+	if got := ComputeRatings(weeks, schedules)["JOHN SMITH"]; got != EloInitialRating {
+		t.Errorf("rating after a BYE week = %v, want %v", got, EloInitialRating)
+	}
+}
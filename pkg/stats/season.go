@@ -0,0 +1,151 @@
+package stats
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/myusername/dart-statistic-scraper/pkg/models"
+	"github.com/myusername/dart-statistic-scraper/pkg/parser"
+)
+
+// Metrics accepted by the season leaderboard functions
+const (
+	MetricPPD          = "ppd"
+	MetricMPR          = "mpr"
+	MetricWins         = "wins"
+	MetricWinPct       = "winpct"
+	MetricHatTricks    = "hattricks"
+	MetricHighScore    = "highscore"
+	MetricHighCheckout = "highcheckout"
+)
+
+// MinQualifyingGames is the number of season games a player needs to be
+// considered for season awards such as team MVP
+var MinQualifyingGames = 10
+
+// TeamMVPs returns each team's most valuable player for the season according to
+// metric (one of the Metric constants; unknown metrics fall back to PPD). Only
+// players with at least MinQualifyingGames games are considered. The result is
+// keyed by normalized team name.
+func TeamMVPs(weeks []*models.WeeklyStats, metric string) map[string]models.PlayerSeasonStat {
+	mvps := make(map[string]models.PlayerSeasonStat)
+
+	for _, player := range aggregatePlayers(weeks) {
+		if player.GamesPlayed < MinQualifyingGames {
+			continue
+		}
+
+		team := parser.NormalizeTeamName(player.Team)
+		current, found := mvps[team]
+		if !found || betterSeason(player, current, metric) {
+			mvps[team] = player
+		}
+	}
+
+	return mvps
+}
+
+// AggregateSeason combines weekly stats into season-to-date totals. The same
+// player on the same team merges across weeks; games and hat tricks are
+// summed, PPD and MPR are averaged using AverageWeighting (games played by
+// default), and high score/checkout keep the season best.
+func AggregateSeason(weeks []*models.WeeklyStats) *models.SeasonStats {
+	season := &models.SeasonStats{Players: make(map[string]models.PlayerSeasonStat)}
+	for _, weeklyStats := range sortedWeeks(weeks) {
+		season.Weeks = append(season.Weeks, weeklyStats.Week)
+	}
+	for _, player := range aggregatePlayers(weeks) {
+		season.Players[seasonKey(player)] = player
+	}
+	return season
+}
+
+// metricValue returns the value of a season metric for a player
+func metricValue(player models.PlayerSeasonStat, metric string) float64 {
+	switch strings.ToLower(metric) {
+	case MetricMPR:
+		return player.MPR
+	case MetricWins:
+		return float64(player.GamesWon)
+	case MetricWinPct:
+		if player.GamesPlayed == 0 {
+			return 0
+		}
+		return float64(player.GamesWon) / float64(player.GamesPlayed) * 100
+	case MetricHatTricks:
+		return float64(player.HatTricks)
+	case MetricHighScore:
+		return float64(player.HighScore)
+	case MetricHighCheckout:
+		return float64(player.HighCheckout)
+	default:
+		return player.PPD
+	}
+}
+
+// betterSeason reports whether a ranks ahead of b on metric, breaking ties by
+// games played and then by name
+func betterSeason(a, b models.PlayerSeasonStat, metric string) bool {
+	va, vb := metricValue(a, metric), metricValue(b, metric)
+	if va != vb {
+		return va > vb
+	}
+	if a.GamesPlayed != b.GamesPlayed {
+		return a.GamesPlayed > b.GamesPlayed
+	}
+	return a.PlayerName < b.PlayerName
+}
+
+// aggregatePlayers combines each player's weekly rows into season totals.
+// Players are matched by normalized name and team; PPD and MPR are averaged
+// using AverageWeighting, and high score/checkout keep the season best.
+func aggregatePlayers(weeks []*models.WeeklyStats) []models.PlayerSeasonStat {
+	totals := make(map[string]*models.PlayerSeasonStat)
+	ppdTotals := make(map[string]float64)
+	mprTotals := make(map[string]float64)
+	weightTotals := make(map[string]float64)
+	var keys []string
+
+	for _, weeklyStats := range sortedWeeks(weeks) {
+		for _, player := range weeklyStats.PlayerStats {
+			key := playerKey(player)
+			total, found := totals[key]
+			if !found {
+				total = &models.PlayerSeasonStat{PlayerName: player.PlayerName, Team: player.Team}
+				totals[key] = total
+				keys = append(keys, key)
+			}
+
+			// Keep the most recent rating
+			if player.SancPd != "" {
+				total.SancPd = player.SancPd
+			}
+			total.Weeks++
+			total.GamesPlayed += player.GamesPlayed
+			total.GamesWon += player.GamesWon
+			total.HatTricks += player.HatTricks
+			weight := playerWeight(player)
+			ppdTotals[key] += player.PPD * weight
+			mprTotals[key] += player.MPR * weight
+			weightTotals[key] += weight
+			if player.HighScore > total.HighScore {
+				total.HighScore = player.HighScore
+			}
+			if player.HighCheckout > total.HighCheckout {
+				total.HighCheckout = player.HighCheckout
+			}
+		}
+	}
+
+	sort.Strings(keys)
+	players := make([]models.PlayerSeasonStat, 0, len(keys))
+	for _, key := range keys {
+		total := totals[key]
+		if weightTotals[key] > 0 {
+			total.PPD = ppdTotals[key] / weightTotals[key]
+			total.MPR = mprTotals[key] / weightTotals[key]
+		}
+		players = append(players, *total)
+	}
+	return players
+}
@@ -0,0 +1,49 @@
+package stats
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/myusername/dart-statistic-scraper/pkg/models"
+)
+
+func TestAggregateSeason(t *testing.T) {
+	weeks := []*models.WeeklyStats{
+		{Week: 3, PlayerStats: []models.PlayerStat{
+			{PlayerName: "JOHN SMITH", Team: "HARBOR HILLS", GamesPlayed: 5, GamesWon: 2, PPD: 30, MPR: 3.0, HighScore: 120, HighCheckout: 96},
+			{PlayerName: "MARY JONES", Team: "REDHEADS", GamesPlayed: 12, GamesWon: 7, PPD: 20},
+		}},
+		{Week: 1, PlayerStats: []models.PlayerStat{
+			{PlayerName: "JOHN SMITH", Team: "HARBOR HILLS", GamesPlayed: 10, GamesWon: 6, PPD: 20, MPR: 2.0, HatTricks: 1, HighScore: 100, HighCheckout: 40},
+			{PlayerName: "MARY JONES", Team: "REDHEADS", GamesPlayed: 8, GamesWon: 4, PPD: 15},
+		}},
+		// Spelling differences still merge with the same player and team
+		{Week: 2, PlayerStats: []models.PlayerStat{
+			{PlayerName: "john  smith", Team: "Harbor Hills", GamesPlayed: 5, GamesWon: 3, PPD: 26, MPR: 2.6, HatTricks: 2, HighScore: 140, HighCheckout: 30},
+		}},
+	}
+
+	season := AggregateSeason(weeks)
+	if !reflect.DeepEqual(season.Weeks, []int{1, 2, 3}) {
+		t.Errorf("Weeks = %v, want [1 2 3]", season.Weeks)
+	}
+	if len(season.Players) != 2 {
+		t.Fatalf("got %d players, want 2: %+v", len(season.Players), season.Players)
+	}
+
+	john := season.Players[playerKey(models.PlayerStat{PlayerName: "JOHN SMITH", Team: "HARBOR HILLS"})]
+	// PPD weighted by games: (10*20 + 5*26 + 5*30) / 20
+	if math.Abs(john.PPD-24) > 1e-9 || math.Abs(john.MPR-2.4) > 1e-9 {
+		t.Errorf("JOHN SMITH PPD, MPR = %v, %v; want 24, 2.4", john.PPD, john.MPR)
+	}
+	if john.Weeks != 3 || john.GamesPlayed != 20 || john.GamesWon != 11 || john.HatTricks != 3 ||
+		john.HighScore != 140 || john.HighCheckout != 96 {
+		t.Errorf("JOHN SMITH totals = %+v", john)
+	}
+
+	mary := season.Players[playerKey(models.PlayerStat{PlayerName: "MARY JONES", Team: "REDHEADS"})]
+	if math.Abs(mary.PPD-18) > 1e-9 || mary.GamesPlayed != 20 || mary.GamesWon != 11 {
+		t.Errorf("MARY JONES totals = %+v, want 20 games, 11 wins, 18 PPD", mary)
+	}
+}
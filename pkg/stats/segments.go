@@ -0,0 +1,87 @@
+package stats
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/myusername/dart-statistic-scraper/pkg/models"
+)
+
+// SegmentRange names a run of weeks within a season, such as the first half
+type SegmentRange struct {
+	Name      string
+	FirstWeek int
+	LastWeek  int
+}
+
+// ParseSegments reads segment ranges written as "name:first-last" separated by
+// commas, e.g. "first:1-13,second:14-26"
+func ParseSegments(spec string) ([]SegmentRange, error) {
+	var segments []SegmentRange
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		name, weeks, found := strings.Cut(part, ":")
+		first, last, foundRange := strings.Cut(weeks, "-")
+		if !found || !foundRange || strings.TrimSpace(name) == "" {
+			return nil, fmt.Errorf("invalid segment %q, expected name:first-last", part)
+		}
+
+		firstWeek, err := strconv.Atoi(strings.TrimSpace(first))
+		if err != nil {
+			return nil, fmt.Errorf("invalid first week in segment %q: %w", part, err)
+		}
+		lastWeek, err := strconv.Atoi(strings.TrimSpace(last))
+		if err != nil {
+			return nil, fmt.Errorf("invalid last week in segment %q: %w", part, err)
+		}
+		if lastWeek < firstWeek {
+			return nil, fmt.Errorf("segment %q ends before it starts", part)
+		}
+
+		segments = append(segments, SegmentRange{Name: strings.TrimSpace(name), FirstWeek: firstWeek, LastWeek: lastWeek})
+	}
+	return segments, nil
+}
+
+// SegmentForWeek returns the name of the first segment containing week, or an
+// empty string if none does
+func SegmentForWeek(segments []SegmentRange, week int) string {
+	for _, segment := range segments {
+		if week >= segment.FirstWeek && week <= segment.LastWeek {
+			return segment.Name
+		}
+	}
+	return ""
+}
+
+// TagSegments sets the segment of each week that doesn't already have one from
+// its page, using the configured ranges
+func TagSegments(weeks []*models.WeeklyStats, segments []SegmentRange) {
+	for _, weeklyStats := range weeks {
+		if weeklyStats != nil && weeklyStats.Segment == "" {
+			weeklyStats.Segment = SegmentForWeek(segments, weeklyStats.Week)
+		}
+	}
+}
+
+// FilterSegment returns the weeks in a segment, matched case-insensitively, so
+// they can be passed to the aggregation and leaderboard functions. An empty
+// segment returns all weeks.
+func FilterSegment(weeks []*models.WeeklyStats, segment string) []*models.WeeklyStats {
+	if segment == "" {
+		return weeks
+	}
+
+	var filtered []*models.WeeklyStats
+	for _, weeklyStats := range weeks {
+		if weeklyStats != nil && strings.EqualFold(weeklyStats.Segment, segment) {
+			filtered = append(filtered, weeklyStats)
+		}
+	}
+	return filtered
+}
@@ -0,0 +1,81 @@
+package stats
+
+import (
+	"fmt"
+
+	"github.com/myusername/dart-statistic-scraper/pkg/models"
+	"github.com/myusername/dart-statistic-scraper/pkg/parser"
+)
+
+// SeriesResult summarizes every scored match between a team and one opponent
+type SeriesResult struct {
+	Team         string
+	Opponent     string
+	Matches      int
+	Wins         int
+	Losses       int
+	Ties         int
+	GamesFor     int
+	GamesAgainst int
+}
+
+// TeamSeries returns the season series between each pair of teams that met in
+// a scored match, keyed by normalized team name and then normalized opponent
+// name. Every pairing appears from both sides. Scores come from the schedule
+// when present and from the weeks' team stats otherwise (see ApplyMatchScores).
+// A pairing listed twice in the same week is counted once.
+func TeamSeries(weeks []*models.WeeklyStats, schedules []models.MatchSchedule) map[string]map[string]SeriesResult {
+	series := make(map[string]map[string]SeriesResult)
+	seen := make(map[string]bool)
+
+	for _, match := range ApplyMatchScores(weeks, schedules) {
+		if !match.HasScore {
+			continue
+		}
+
+		home := parser.NormalizeTeamName(match.HomeTeam)
+		away := parser.NormalizeTeamName(match.AwayTeam)
+		if home == "" || away == "" || home == away {
+			continue
+		}
+		key := matchupKey(match.Week, home, away)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		addSeriesMatch(series, home, away, match.HomeTeam, match.AwayTeam, match.HomeScore, match.AwayScore)
+		addSeriesMatch(series, away, home, match.AwayTeam, match.HomeTeam, match.AwayScore, match.HomeScore)
+	}
+	return series
+}
+
+// addSeriesMatch adds one match to a team's series against an opponent
+func addSeriesMatch(series map[string]map[string]SeriesResult, team, opponent, teamName, opponentName string, gamesFor, gamesAgainst int) {
+	if series[team] == nil {
+		series[team] = make(map[string]SeriesResult)
+	}
+
+	result := series[team][opponent]
+	if result.Matches == 0 {
+		result.Team = teamName
+		result.Opponent = opponentName
+	}
+	result.Matches++
+	result.GamesFor += gamesFor
+	result.GamesAgainst += gamesAgainst
+	switch {
+	case gamesFor > gamesAgainst:
+		result.Wins++
+	case gamesFor < gamesAgainst:
+		result.Losses++
+	default:
+		result.Ties++
+	}
+	series[team][opponent] = result
+}
+
+// String formats the series from the team's side, e.g. "2-1-0 (35-28)"
+func (r SeriesResult) String() string {
+	return fmt.Sprintf("%d-%d-%d (%d-%d)", r.Wins, r.Losses, r.Ties, r.GamesFor, r.GamesAgainst)
+}
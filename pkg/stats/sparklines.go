@@ -0,0 +1,63 @@
+package stats
+
+import (
+	"github.com/myusername/dart-statistic-scraper/pkg/models"
+)
+
+// SparklineFillPrevious fills weeks a player missed with their previous value.
+// When false, or before a player's first week, SparklineSentinel is used.
+var SparklineFillPrevious = true
+
+// SparklineSentinel marks weeks with no value in a sparkline series
+var SparklineSentinel = 0.0
+
+// PlayerSparklines returns each player's weekly PPD as a series ready to render
+// as a sparkline, keyed by normalized player name. Every series has one value
+// per week present in weeks, ordered by week.
+func PlayerSparklines(weeks []*models.WeeklyStats) map[string][]float64 {
+	sorted := sortedWeeks(weeks)
+
+	// Weighted PPD per player for each week, in case a player shows up twice
+	type weekTotal struct {
+		ppdTotal float64
+		weight   float64
+	}
+	weekTotals := make([]map[string]*weekTotal, len(sorted))
+	players := make(map[string]bool)
+	for i, weeklyStats := range sorted {
+		weekTotals[i] = make(map[string]*weekTotal)
+		for _, player := range weeklyStats.PlayerStats {
+			if player.GamesPlayed == 0 {
+				continue
+			}
+			name := normalizePlayerName(player.PlayerName)
+			players[name] = true
+
+			total, found := weekTotals[i][name]
+			if !found {
+				total = &weekTotal{}
+				weekTotals[i][name] = total
+			}
+			total.ppdTotal += player.PPD * playerWeight(player)
+			total.weight += playerWeight(player)
+		}
+	}
+
+	sparklines := make(map[string][]float64, len(players))
+	for name := range players {
+		series := make([]float64, len(sorted))
+		last, seen := SparklineSentinel, false
+		for i := range sorted {
+			if total, found := weekTotals[i][name]; found {
+				last, seen = total.ppdTotal/total.weight, true
+				series[i] = last
+			} else if SparklineFillPrevious && seen {
+				series[i] = last
+			} else {
+				series[i] = SparklineSentinel
+			}
+		}
+		sparklines[name] = series
+	}
+	return sparklines
+}
@@ -0,0 +1,52 @@
+// Package stats provides analytics computed from scraped weekly statistics
+package stats
+
+import (
+	"strings"
+
+	"github.com/myusername/dart-statistic-scraper/pkg/models"
+	"github.com/myusername/dart-statistic-scraper/pkg/parser"
+)
+
+// normalizePlayerName standardizes a player name for comparison across weeks
+func normalizePlayerName(name string) string {
+	return strings.ToUpper(strings.Join(strings.Fields(name), " "))
+}
+
+// playerKey identifies the same player on the same team across weeks
+func playerKey(player models.PlayerStat) string {
+	return normalizePlayerName(player.PlayerName) + "|" + parser.NormalizeTeamName(player.Team)
+}
+
+// seasonKey identifies a season total by normalized player name and team
+func seasonKey(player models.PlayerSeasonStat) string {
+	return playerKey(models.PlayerStat{PlayerName: player.PlayerName, Team: player.Team})
+}
+
+// CurrentWeek returns the week reports should treat as current: override when
+// it is positive, otherwise the highest week that has parsed player stats
+func CurrentWeek(weeks []*models.WeeklyStats, override int) int {
+	if override > 0 {
+		return override
+	}
+
+	current := 0
+	for _, weeklyStats := range weeks {
+		if weeklyStats != nil && len(weeklyStats.PlayerStats) > 0 && weeklyStats.Week > current {
+			current = weeklyStats.Week
+		}
+	}
+	return current
+}
+
+// FilterByMinGames returns the players who played at least minGames games,
+// leaving players untouched
+func FilterByMinGames(players []models.PlayerStat, minGames int) []models.PlayerStat {
+	filtered := make([]models.PlayerStat, 0, len(players))
+	for _, player := range players {
+		if player.GamesPlayed >= minGames {
+			filtered = append(filtered, player)
+		}
+	}
+	return filtered
+}
@@ -0,0 +1,41 @@
+package stats
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/myusername/dart-statistic-scraper/pkg/models"
+)
+
+func TestFilterByMinGames(t *testing.T) {
+	players := []models.PlayerStat{
+		{PlayerName: "JOHN SMITH", GamesPlayed: 10},
+		{PlayerName: "MARY JONES", GamesPlayed: 2},
+		{PlayerName: "TOM NG", GamesPlayed: 3},
+		{PlayerName: "AMY LEE", GamesPlayed: 0},
+	}
+	original := append([]models.PlayerStat(nil), players...)
+
+	tests := []struct {
+		minGames int
+		want     []string
+	}{
+		{3, []string{"JOHN SMITH", "TOM NG"}},
+		{11, nil},
+		{0, []string{"JOHN SMITH", "MARY JONES", "TOM NG", "AMY LEE"}},
+	}
+	for _, tt := range tests {
+		filtered := FilterByMinGames(players, tt.minGames)
+		if got := playerNames(filtered); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("FilterByMinGames(%d) = %v, want %v", tt.minGames, got, tt.want)
+		}
+
+		// Changing the result leaves the input alone
+		for i := range filtered {
+			filtered[i].GamesPlayed = -1
+		}
+		if !reflect.DeepEqual(players, original) {
+			t.Fatalf("FilterByMinGames(%d) changed its input: %+v", tt.minGames, players)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package stats
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/myusername/dart-statistic-scraper/pkg/models"
+)
+
+// Weighting selects how PPD and MPR are weighted when rows are averaged together
+type Weighting int
+
+// Supported weightings
+const (
+	// WeightGames weights each row by games played
+	WeightGames Weighting = iota
+	// WeightEqual gives every row that has games the same weight
+	WeightEqual
+	// WeightDarts weights each row by darts thrown, falling back to games
+	// played for rows where darts thrown wasn't parsed
+	WeightDarts
+)
+
+// AverageWeighting is the weighting used by every averaged statistic in this
+// package. The default, WeightGames, matches how most leagues compute season
+// PPD and MPR.
+var AverageWeighting = WeightGames
+
+// ParseWeighting reads a weighting name: "games", "equal" or "darts"
+func ParseWeighting(name string) (Weighting, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "games", "":
+		return WeightGames, nil
+	case "equal":
+		return WeightEqual, nil
+	case "darts":
+		return WeightDarts, nil
+	}
+	return WeightGames, fmt.Errorf("unknown weighting %q", name)
+}
+
+// playerWeight returns the weight of a player's row
+func playerWeight(player models.PlayerStat) float64 {
+	return averageWeight(player.GamesPlayed, player.DartsThrown)
+}
+
+// teamWeight returns the weight of a team's row
+func teamWeight(team models.TeamStat) float64 {
+	return averageWeight(team.GamesPlayed, team.DartsThrown)
+}
+
+// averageWeight applies AverageWeighting; rows without games have no weight
+func averageWeight(games, darts int) float64 {
+	if games <= 0 {
+		return 0
+	}
+
+	switch AverageWeighting {
+	case WeightEqual:
+		return 1
+	case WeightDarts:
+		if darts > 0 {
+			return float64(darts)
+		}
+	}
+	return float64(games)
+}
@@ -0,0 +1,308 @@
+package storage
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	// Register the pure-Go SQLite driver
+	_ "modernc.org/sqlite"
+
+	"github.com/myusername/dart-statistic-scraper/pkg/models"
+)
+
+// sqliteSchema creates the tables used by DB. Weeks are keyed by division and
+// week, players and teams also by name; position keeps the order they
+// appeared on the page.
+const sqliteSchema = `
+CREATE TABLE IF NOT EXISTS weeks (
+	division       TEXT NOT NULL DEFAULT '',
+	week           INTEGER NOT NULL,
+	date           TEXT NOT NULL,
+	segment        TEXT NOT NULL,
+	schema_version INTEGER NOT NULL,
+	saved_at       TIMESTAMP NOT NULL,
+	PRIMARY KEY (division, week)
+);
+CREATE TABLE IF NOT EXISTS players (
+	division      TEXT NOT NULL DEFAULT '',
+	week          INTEGER NOT NULL,
+	player_name   TEXT NOT NULL,
+	team          TEXT NOT NULL,
+	position      INTEGER NOT NULL,
+	opponent      TEXT NOT NULL,
+	sanc_pd       TEXT NOT NULL,
+	games_played  INTEGER NOT NULL,
+	games_won     INTEGER NOT NULL,
+	ppd           REAL NOT NULL,
+	mpr           REAL NOT NULL,
+	hat_tricks    INTEGER NOT NULL,
+	high_score    INTEGER NOT NULL,
+	high_checkout INTEGER NOT NULL,
+	darts_thrown  INTEGER NOT NULL,
+	plus_minus    INTEGER NOT NULL,
+	seed_number   INTEGER NOT NULL DEFAULT 0,
+	PRIMARY KEY (division, week, player_name, team)
+);
+CREATE TABLE IF NOT EXISTS teams (
+	division     TEXT NOT NULL DEFAULT '',
+	week         INTEGER NOT NULL,
+	team_name    TEXT NOT NULL,
+	position     INTEGER NOT NULL,
+	games_played INTEGER NOT NULL,
+	games_won    INTEGER NOT NULL,
+	ppd          REAL NOT NULL,
+	mpr          REAL NOT NULL,
+	darts_thrown INTEGER NOT NULL,
+	PRIMARY KEY (division, week, team_name)
+);
+`
+
+// sqliteAddedColumns are columns added after the tables were first created,
+// so databases written by older versions are brought up to date when opened
+var sqliteAddedColumns = []struct {
+	table, column, definition string
+}{
+	{"players", "seed_number", "INTEGER NOT NULL DEFAULT 0"},
+}
+
+// DB keeps weekly statistics in a SQLite database so they can be queried with SQL
+type DB struct {
+	db       *sql.DB
+	division string
+}
+
+// OpenDB opens or creates the SQLite database at path and creates its tables
+func OpenDB(path string) (*DB, error) {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+	if _, err := db.Exec(sqliteSchema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to create tables: %w", err)
+	}
+	if err := addMissingColumns(db); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to migrate tables: %w", err)
+	}
+	if err := addDivisionKeys(db); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to migrate tables: %w", err)
+	}
+	return &DB{db: db}, nil
+}
+
+// addDivisionKeys rebuilds tables written before divisions were stored, which
+// are keyed by week alone, moving their rows to the empty division
+func addDivisionKeys(db *sql.DB) error {
+	var count int
+	if err := db.QueryRow("SELECT COUNT(*) FROM pragma_table_info('weeks') WHERE name = 'division'").Scan(&count); err != nil {
+		return err
+	}
+	if count > 0 {
+		return nil
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	columns := map[string]string{
+		"weeks": "week, date, segment, schema_version, saved_at",
+		"players": "week, player_name, team, position, opponent, sanc_pd, games_played, games_won, ppd, mpr, " +
+			"hat_tricks, high_score, high_checkout, darts_thrown, plus_minus, seed_number",
+		"teams": "week, team_name, position, games_played, games_won, ppd, mpr, darts_thrown",
+	}
+	tables := []string{"weeks", "players", "teams"}
+	for _, table := range tables {
+		if _, err := tx.Exec("ALTER TABLE " + table + " RENAME TO old_" + table); err != nil {
+			return err
+		}
+	}
+	if _, err := tx.Exec(sqliteSchema); err != nil {
+		return err
+	}
+	for _, table := range tables {
+		if _, err := tx.Exec("INSERT INTO " + table + " (" + columns[table] + ") SELECT " + columns[table] + " FROM old_" + table); err != nil {
+			return err
+		}
+	}
+	for _, table := range []string{"old_players", "old_teams", "old_weeks"} {
+		if _, err := tx.Exec("DROP TABLE " + table); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// Division returns a view of the database holding only the named division's
+// weeks. It shares the database connection, so closing either closes both.
+func (d *DB) Division(name string) (Store, error) {
+	return &DB{db: d.db, division: name}, nil
+}
+
+// addMissingColumns adds the sqliteAddedColumns a database doesn't have yet
+func addMissingColumns(db *sql.DB) error {
+	for _, added := range sqliteAddedColumns {
+		var count int
+		err := db.QueryRow("SELECT COUNT(*) FROM pragma_table_info(?) WHERE name = ?", added.table, added.column).Scan(&count)
+		if err != nil {
+			return err
+		}
+		if count > 0 {
+			continue
+		}
+		if _, err := db.Exec("ALTER TABLE " + added.table + " ADD COLUMN " + added.column + " " + added.definition); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Close closes the database
+func (d *DB) Close() error {
+	return d.db.Close()
+}
+
+// SaveWeeklyStats writes the stats for a week, replacing any earlier copy.
+// Players are upserted on (division, week, player name, team) and teams on
+// (division, week, team name), so a player listed twice in a week keeps the
+// later row.
+func (d *DB) SaveWeeklyStats(ws *models.WeeklyStats) error {
+	tx, err := d.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to save week %d: %w", ws.Week, err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`INSERT INTO weeks (division, week, date, segment, schema_version, saved_at) VALUES (?, ?, ?, ?, ?, ?)
+		ON CONFLICT (division, week) DO UPDATE SET date = excluded.date, segment = excluded.segment,
+			schema_version = excluded.schema_version, saved_at = excluded.saved_at`,
+		d.division, ws.Week, ws.Date, ws.Segment, models.SchemaVersion, time.Now().UTC()); err != nil {
+		return fmt.Errorf("failed to save week %d: %w", ws.Week, err)
+	}
+
+	// Drop rows from an earlier copy that the new one no longer has
+	for _, table := range []string{"players", "teams"} {
+		if _, err := tx.Exec("DELETE FROM "+table+" WHERE division = ? AND week = ?", d.division, ws.Week); err != nil {
+			return fmt.Errorf("failed to replace week %d %s: %w", ws.Week, table, err)
+		}
+	}
+
+	for i, player := range ws.PlayerStats {
+		if _, err := tx.Exec(`INSERT INTO players (division, week, player_name, team, position, opponent, sanc_pd,
+				games_played, games_won, ppd, mpr, hat_tricks, high_score, high_checkout, darts_thrown, plus_minus, seed_number)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+			ON CONFLICT (division, week, player_name, team) DO UPDATE SET position = excluded.position,
+				opponent = excluded.opponent, sanc_pd = excluded.sanc_pd, games_played = excluded.games_played,
+				games_won = excluded.games_won, ppd = excluded.ppd, mpr = excluded.mpr,
+				hat_tricks = excluded.hat_tricks, high_score = excluded.high_score,
+				high_checkout = excluded.high_checkout, darts_thrown = excluded.darts_thrown,
+				plus_minus = excluded.plus_minus, seed_number = excluded.seed_number`,
+			d.division, ws.Week, player.PlayerName, player.Team, i, player.Opponent, player.SancPd,
+			player.GamesPlayed, player.GamesWon, player.PPD, player.MPR, player.HatTricks,
+			player.HighScore, player.HighCheckout, player.DartsThrown, player.PlusMinus, player.SeedNumber); err != nil {
+			return fmt.Errorf("failed to save week %d player %s: %w", ws.Week, player.PlayerName, err)
+		}
+	}
+
+	for i, team := range ws.TeamStats {
+		if _, err := tx.Exec(`INSERT INTO teams (division, week, team_name, position, games_played, games_won, ppd, mpr, darts_thrown)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+			ON CONFLICT (division, week, team_name) DO UPDATE SET position = excluded.position,
+				games_played = excluded.games_played, games_won = excluded.games_won,
+				ppd = excluded.ppd, mpr = excluded.mpr, darts_thrown = excluded.darts_thrown`,
+			d.division, ws.Week, team.TeamName, i, team.GamesPlayed, team.GamesWon, team.PPD, team.MPR, team.DartsThrown); err != nil {
+			return fmt.Errorf("failed to save week %d team %s: %w", ws.Week, team.TeamName, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to save week %d: %w", ws.Week, err)
+	}
+	return nil
+}
+
+// LoadWeek reads the stats stored for a week, returning ErrWeekNotFound if
+// the week has never been saved
+func (d *DB) LoadWeek(week int) (*models.WeeklyStats, error) {
+	ws := &models.WeeklyStats{Week: week, Division: d.division}
+	var schemaVersion int
+	err := d.db.QueryRow("SELECT date, segment, schema_version FROM weeks WHERE division = ? AND week = ?", d.division, week).Scan(&ws.Date, &ws.Segment, &schemaVersion)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("week %d: %w", week, ErrWeekNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read week %d: %w", week, err)
+	}
+
+	// Rows from a newer version may hold data we'd drop; older rows are
+	// migrated once they are loaded
+	if schemaVersion > models.SchemaVersion {
+		return nil, fmt.Errorf("week %d was stored with schema version %d, newer than supported version %d",
+			week, schemaVersion, models.SchemaVersion)
+	}
+
+	rows, err := d.db.Query(`SELECT player_name, team, opponent, sanc_pd, games_played, games_won, ppd, mpr,
+			hat_tricks, high_score, high_checkout, darts_thrown, plus_minus, seed_number
+		FROM players WHERE division = ? AND week = ? ORDER BY position`, d.division, week)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read week %d players: %w", week, err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var p models.PlayerStat
+		if err := rows.Scan(&p.PlayerName, &p.Team, &p.Opponent, &p.SancPd, &p.GamesPlayed, &p.GamesWon,
+			&p.PPD, &p.MPR, &p.HatTricks, &p.HighScore, &p.HighCheckout, &p.DartsThrown, &p.PlusMinus, &p.SeedNumber); err != nil {
+			return nil, fmt.Errorf("failed to read week %d players: %w", week, err)
+		}
+		p.Rating, _ = models.ParseRating(p.SancPd)
+		ws.PlayerStats = append(ws.PlayerStats, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read week %d players: %w", week, err)
+	}
+
+	teamRows, err := d.db.Query(`SELECT team_name, games_played, games_won, ppd, mpr, darts_thrown
+		FROM teams WHERE division = ? AND week = ? ORDER BY position`, d.division, week)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read week %d teams: %w", week, err)
+	}
+	defer teamRows.Close()
+	for teamRows.Next() {
+		var t models.TeamStat
+		if err := teamRows.Scan(&t.TeamName, &t.GamesPlayed, &t.GamesWon, &t.PPD, &t.MPR, &t.DartsThrown); err != nil {
+			return nil, fmt.Errorf("failed to read week %d teams: %w", week, err)
+		}
+		ws.TeamStats = append(ws.TeamStats, t)
+	}
+	if err := teamRows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read week %d teams: %w", week, err)
+	}
+
+	migrateWeek(ws, schemaVersion)
+	return ws, nil
+}
+
+// Weeks returns the stored weeks in ascending order
+func (d *DB) Weeks() ([]int, error) {
+	rows, err := d.db.Query("SELECT week FROM weeks WHERE division = ? ORDER BY week", d.division)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list weeks: %w", err)
+	}
+	defer rows.Close()
+
+	var weeks []int
+	for rows.Next() {
+		var week int
+		if err := rows.Scan(&week); err != nil {
+			return nil, fmt.Errorf("failed to list weeks: %w", err)
+		}
+		weeks = append(weeks, week)
+	}
+	return weeks, rows.Err()
+}
@@ -0,0 +1,207 @@
+package storage
+
+import (
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/myusername/dart-statistic-scraper/pkg/models"
+)
+
+// openTestDB opens a database in a temporary directory, closed when the test ends
+func openTestDB(t *testing.T) *DB {
+	t.Helper()
+	db, err := OpenDB(filepath.Join(t.TempDir(), "stats.db"))
+	if err != nil {
+		t.Fatalf("OpenDB: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+// testWeek returns a week with two teams and their players
+func testWeek(week int) *models.WeeklyStats {
+	return &models.WeeklyStats{
+		Week:    week,
+		Date:    "October 5, 2024",
+		Segment: "first",
+		PlayerStats: []models.PlayerStat{
+			{PlayerName: "JOHN SMITH", Team: "BRIDGE INN 1", Opponent: "REDHEADS", SancPd: "AA", Rating: models.RatingAA,
+				GamesPlayed: 10, GamesWon: 7, PPD: 25.3, MPR: 2.81, HatTricks: 2, HighScore: 140, HighCheckout: 96,
+				DartsThrown: 450, PlusMinus: 3, SeedNumber: 12},
+			{PlayerName: "MARY JO ANNE", Team: "REDHEADS", Opponent: "BRIDGE INN 1", SancPd: "B", Rating: models.RatingB,
+				GamesPlayed: 8, GamesWon: 3, PPD: 18.4, MPR: 1.92, PlusMinus: -2},
+		},
+		TeamStats: []models.TeamStat{
+			{TeamName: "BRIDGE INN 1", GamesPlayed: 20, GamesWon: 12, PPD: 22.1, MPR: 2.4, DartsThrown: 900},
+			{TeamName: "REDHEADS", GamesPlayed: 20, GamesWon: 8, PPD: 19.7, MPR: 2.0},
+		},
+	}
+}
+
+func TestDBDivisionsKeepTheirOwnWeeks(t *testing.T) {
+	db := openTestDB(t)
+	testDivisionsKeepTheirOwnWeeks(t, db)
+}
+
+func TestDBMigratesWeekKeyedTables(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "old.db")
+
+	// Create the tables the way versions before divisions did
+	sqlDB, err := sql.Open("sqlite", path)
+	if err != nil {
+		t.Fatalf("sql.Open: %v", err)
+	}
+	_, err = sqlDB.Exec(`
+CREATE TABLE weeks (week INTEGER PRIMARY KEY, date TEXT NOT NULL, segment TEXT NOT NULL,
+	schema_version INTEGER NOT NULL, saved_at TIMESTAMP NOT NULL);
+CREATE TABLE players (week INTEGER NOT NULL REFERENCES weeks(week), player_name TEXT NOT NULL, team TEXT NOT NULL,
+	position INTEGER NOT NULL, opponent TEXT NOT NULL, sanc_pd TEXT NOT NULL, games_played INTEGER NOT NULL,
+	games_won INTEGER NOT NULL, ppd REAL NOT NULL, mpr REAL NOT NULL, hat_tricks INTEGER NOT NULL,
+	high_score INTEGER NOT NULL, high_checkout INTEGER NOT NULL, darts_thrown INTEGER NOT NULL,
+	plus_minus INTEGER NOT NULL, PRIMARY KEY (week, player_name, team));
+CREATE TABLE teams (week INTEGER NOT NULL REFERENCES weeks(week), team_name TEXT NOT NULL, position INTEGER NOT NULL,
+	games_played INTEGER NOT NULL, games_won INTEGER NOT NULL, ppd REAL NOT NULL, mpr REAL NOT NULL,
+	darts_thrown INTEGER NOT NULL, PRIMARY KEY (week, team_name));
+INSERT INTO weeks VALUES (2, 'October 5, 2024', '', 11, '2024-10-06 00:00:00');
+INSERT INTO players VALUES (2, 'JOHN SMITH', 'BRIDGE INN 1', 0, 'REDHEADS', 'AA', 10, 7, 25.3, 2.81, 2, 140, 96, 450, 3);
+INSERT INTO teams VALUES (2, 'BRIDGE INN 1', 0, 20, 12, 22.1, 2.4, 900);`)
+	if err != nil {
+		t.Fatalf("create old tables: %v", err)
+	}
+	sqlDB.Close()
+
+	db, err := OpenDB(path)
+	if err != nil {
+		t.Fatalf("OpenDB of an older database: %v", err)
+	}
+	defer db.Close()
+	got, err := db.LoadWeek(2)
+	if err != nil {
+		t.Fatalf("LoadWeek: %v", err)
+	}
+	if len(got.PlayerStats) != 1 || got.PlayerStats[0].PlayerName != "JOHN SMITH" || got.PlayerStats[0].HighCheckout != 96 {
+		t.Errorf("migrated players = %+v", got.PlayerStats)
+	}
+	if len(got.TeamStats) != 1 || got.TeamStats[0].DartsThrown != 900 {
+		t.Errorf("migrated teams = %+v", got.TeamStats)
+	}
+
+	// Divisions can now be saved next to the migrated week
+	division, _ := db.Division("SUN2")
+	if err := division.SaveWeeklyStats(testWeek(2)); err != nil {
+		t.Fatalf("SaveWeeklyStats in a division: %v", err)
+	}
+}
+
+func TestDBRoundTrip(t *testing.T) {
+	db := openTestDB(t)
+	want := testWeek(3)
+
+	if err := db.SaveWeeklyStats(want); err != nil {
+		t.Fatalf("SaveWeeklyStats: %v", err)
+	}
+	got, err := db.LoadWeek(3)
+	if err != nil {
+		t.Fatalf("LoadWeek: %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("LoadWeek(3) =\n%+v\nwant\n%+v", got, want)
+	}
+
+	weeks, err := db.Weeks()
+	if err != nil {
+		t.Fatalf("Weeks: %v", err)
+	}
+	if !reflect.DeepEqual(weeks, []int{3}) {
+		t.Errorf("Weeks() = %v, want [3]", weeks)
+	}
+}
+
+func TestDBSaveReplacesWeek(t *testing.T) {
+	db := openTestDB(t)
+	if err := db.SaveWeeklyStats(testWeek(3)); err != nil {
+		t.Fatalf("SaveWeeklyStats: %v", err)
+	}
+
+	// A later copy with one player fewer drops the missing row
+	want := testWeek(3)
+	want.PlayerStats = want.PlayerStats[:1]
+	if err := db.SaveWeeklyStats(want); err != nil {
+		t.Fatalf("SaveWeeklyStats: %v", err)
+	}
+	got, err := db.LoadWeek(3)
+	if err != nil {
+		t.Fatalf("LoadWeek: %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("LoadWeek(3) =\n%+v\nwant\n%+v", got, want)
+	}
+}
+
+func TestDBAddsSeedNumberColumn(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "old.db")
+
+	// Create the players table the way versions before seed numbers did
+	db, err := OpenDB(path)
+	if err != nil {
+		t.Fatalf("OpenDB: %v", err)
+	}
+	if _, err := db.db.Exec("ALTER TABLE players DROP COLUMN seed_number"); err != nil {
+		t.Fatalf("drop column: %v", err)
+	}
+	db.Close()
+
+	db, err = OpenDB(path)
+	if err != nil {
+		t.Fatalf("OpenDB of an older database: %v", err)
+	}
+	defer db.Close()
+	want := testWeek(3)
+	if err := db.SaveWeeklyStats(want); err != nil {
+		t.Fatalf("SaveWeeklyStats: %v", err)
+	}
+	got, err := db.LoadWeek(3)
+	if err != nil {
+		t.Fatalf("LoadWeek: %v", err)
+	}
+	if got.PlayerStats[0].SeedNumber != 12 || got.PlayerStats[1].SeedNumber != 0 {
+		t.Errorf("seed numbers = %d, %d, want 12, 0", got.PlayerStats[0].SeedNumber, got.PlayerStats[1].SeedNumber)
+	}
+}
+
+func TestDBLoadMissingWeek(t *testing.T) {
+	db := openTestDB(t)
+	if _, err := db.LoadWeek(7); !errors.Is(err, ErrWeekNotFound) {
+		t.Errorf("LoadWeek(7) error = %v, want ErrWeekNotFound", err)
+	}
+}
+
+func TestDBSchemaVersion(t *testing.T) {
+	db := openTestDB(t)
+	if err := db.SaveWeeklyStats(testWeek(3)); err != nil {
+		t.Fatalf("SaveWeeklyStats: %v", err)
+	}
+
+	// Rows written by an older version are still read
+	if _, err := db.db.Exec("UPDATE weeks SET schema_version = ?", ratingSchemaVersion-1); err != nil {
+		t.Fatalf("downgrade: %v", err)
+	}
+	got, err := db.LoadWeek(3)
+	if err != nil {
+		t.Fatalf("LoadWeek of an older row: %v", err)
+	}
+	if got.PlayerStats[0].Rating != models.RatingAA {
+		t.Errorf("migrated Rating = %q, want %q", got.PlayerStats[0].Rating, models.RatingAA)
+	}
+
+	// Rows written by a newer version are rejected
+	if _, err := db.db.Exec("UPDATE weeks SET schema_version = ?", models.SchemaVersion+1); err != nil {
+		t.Fatalf("upgrade: %v", err)
+	}
+	if _, err := db.LoadWeek(3); err == nil {
+		t.Error("LoadWeek of a newer row succeeded, want an error")
+	}
+}
@@ -0,0 +1,187 @@
+// Package storage persists weekly statistics so later runs can build on earlier ones
+package storage
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/myusername/dart-statistic-scraper/pkg/models"
+	"github.com/myusername/dart-statistic-scraper/pkg/vfs"
+)
+
+// ErrWeekNotFound is returned when a week has not been stored yet
+var ErrWeekNotFound = errors.New("week not found in store")
+
+// Store saves and loads weekly statistics
+type Store interface {
+	SaveWeeklyStats(ws *models.WeeklyStats) error
+	LoadWeek(week int) (*models.WeeklyStats, error)
+	Weeks() ([]int, error)
+	// Division returns a view of the store holding only the named division's
+	// weeks, so divisions scraped together don't overwrite each other. The
+	// empty name is the store itself.
+	Division(name string) (Store, error)
+}
+
+// LoadAll loads every week held by a store, in week order
+func LoadAll(store Store) ([]*models.WeeklyStats, error) {
+	weeks, err := store.Weeks()
+	if err != nil {
+		return nil, err
+	}
+
+	var all []*models.WeeklyStats
+	for _, week := range weeks {
+		ws, err := store.LoadWeek(week)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, ws)
+	}
+	return all, nil
+}
+
+// MaxWeek returns the highest week held by a store, or 0 when it is empty
+func MaxWeek(store Store) (int, error) {
+	weeks, err := store.Weeks()
+	if err != nil {
+		return 0, err
+	}
+
+	maxWeek := 0
+	for _, week := range weeks {
+		if week > maxWeek {
+			maxWeek = week
+		}
+	}
+	return maxWeek, nil
+}
+
+// ratingSchemaVersion is the first schema version with PlayerStat.Rating
+const ratingSchemaVersion = 11
+
+// migrateWeek fills in the fields added to the models since a week was stored
+// with an older schema version
+func migrateWeek(ws *models.WeeklyStats, version int) {
+	if version < ratingSchemaVersion {
+		for i := range ws.PlayerStats {
+			ws.PlayerStats[i].Rating, _ = models.ParseRating(ws.PlayerStats[i].SancPd)
+		}
+	}
+}
+
+// storedWeek is the on-disk format of a single stored week
+type storedWeek struct {
+	SchemaVersion int                 `json:"schemaVersion"`
+	SavedAt       time.Time           `json:"savedAt"`
+	WeeklyStats   *models.WeeklyStats `json:"weeklyStats"`
+}
+
+// FileStore keeps each week as a JSON file in a directory
+type FileStore struct {
+	fsys vfs.FS
+	dir  string
+}
+
+// NewFileStore creates a store rooted at dir on the OS filesystem, creating
+// the directory if needed
+func NewFileStore(dir string) (*FileStore, error) {
+	return NewFileStoreFS(vfs.OS{}, dir)
+}
+
+// NewFileStoreFS creates a store rooted at dir on the given filesystem
+func NewFileStoreFS(fsys vfs.FS, dir string) (*FileStore, error) {
+	if err := fsys.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create store directory: %w", err)
+	}
+	return &FileStore{fsys: fsys, dir: dir}, nil
+}
+
+// SaveWeeklyStats writes the stats for a week, replacing any earlier copy
+func (s *FileStore) SaveWeeklyStats(ws *models.WeeklyStats) error {
+	doc := storedWeek{
+		SchemaVersion: models.SchemaVersion,
+		SavedAt:       time.Now().UTC(),
+		WeeklyStats:   ws,
+	}
+
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode week %d: %w", ws.Week, err)
+	}
+
+	if err := vfs.WriteFile(s.fsys, s.weekPath(ws.Week), data); err != nil {
+		return fmt.Errorf("failed to write week %d: %w", ws.Week, err)
+	}
+	return nil
+}
+
+// LoadWeek reads the stats stored for a week, returning ErrWeekNotFound if
+// the week has never been saved
+func (s *FileStore) LoadWeek(week int) (*models.WeeklyStats, error) {
+	data, err := vfs.ReadFile(s.fsys, s.weekPath(week))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("week %d: %w", week, ErrWeekNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read week %d: %w", week, err)
+	}
+
+	var doc storedWeek
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to decode week %d: %w", week, err)
+	}
+
+	if doc.SchemaVersion > models.SchemaVersion {
+		return nil, fmt.Errorf("week %d was stored with schema version %d, newer than supported version %d",
+			week, doc.SchemaVersion, models.SchemaVersion)
+	}
+
+	// Files written before the schema version was recorded hold the stats directly
+	if doc.WeeklyStats == nil {
+		var ws models.WeeklyStats
+		if err := json.Unmarshal(data, &ws); err != nil {
+			return nil, fmt.Errorf("failed to decode week %d: %w", week, err)
+		}
+		migrateWeek(&ws, 0)
+		return &ws, nil
+	}
+	migrateWeek(doc.WeeklyStats, doc.SchemaVersion)
+	return doc.WeeklyStats, nil
+}
+
+// Weeks returns the stored weeks in ascending order
+func (s *FileStore) Weeks() ([]int, error) {
+	names, err := s.fsys.ReadDir(s.dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list store: %w", err)
+	}
+
+	var weeks []int
+	for _, name := range names {
+		var week int
+		if _, err := fmt.Sscanf(name, "week_%d.json", &week); err == nil && name == filepath.Base(s.weekPath(week)) {
+			weeks = append(weeks, week)
+		}
+	}
+	sort.Ints(weeks)
+	return weeks, nil
+}
+
+// Division returns a store for the named division, kept in a subdirectory
+func (s *FileStore) Division(name string) (Store, error) {
+	if name == "" {
+		return s, nil
+	}
+	return NewFileStoreFS(s.fsys, filepath.Join(s.dir, name))
+}
+
+// weekPath returns the file used to store a week
+func (s *FileStore) weekPath(week int) string {
+	return filepath.Join(s.dir, fmt.Sprintf("week_%d.json", week))
+}
@@ -0,0 +1,109 @@
+package storage
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/myusername/dart-statistic-scraper/pkg/models"
+	"github.com/myusername/dart-statistic-scraper/pkg/vfs"
+)
+
+func TestFileStoreDivisionsKeepTheirOwnWeeks(t *testing.T) {
+	store, err := NewFileStoreFS(vfs.NewMemFS(), "store")
+	if err != nil {
+		t.Fatalf("NewFileStoreFS: %v", err)
+	}
+	testDivisionsKeepTheirOwnWeeks(t, store)
+}
+
+// testDivisionsKeepTheirOwnWeeks saves the same week for two divisions and
+// checks neither overwrites the other
+func testDivisionsKeepTheirOwnWeeks(t *testing.T, store Store) {
+	t.Helper()
+	first, err := store.Division("SUN1")
+	if err != nil {
+		t.Fatalf("Division(SUN1): %v", err)
+	}
+	second, err := store.Division("SUN2")
+	if err != nil {
+		t.Fatalf("Division(SUN2): %v", err)
+	}
+
+	firstWeek := testWeek(3)
+	firstWeek.Division = "SUN1"
+	secondWeek := testWeek(3)
+	secondWeek.Division = "SUN2"
+	secondWeek.PlayerStats = secondWeek.PlayerStats[1:]
+	if err := first.SaveWeeklyStats(firstWeek); err != nil {
+		t.Fatalf("SaveWeeklyStats(SUN1): %v", err)
+	}
+	if err := second.SaveWeeklyStats(secondWeek); err != nil {
+		t.Fatalf("SaveWeeklyStats(SUN2): %v", err)
+	}
+
+	for _, tt := range []struct {
+		store Store
+		want  *models.WeeklyStats
+	}{{first, firstWeek}, {second, secondWeek}} {
+		got, err := tt.store.LoadWeek(3)
+		if err != nil {
+			t.Fatalf("LoadWeek(%s): %v", tt.want.Division, err)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("LoadWeek(3) in %s =\n%+v\nwant\n%+v", tt.want.Division, got, tt.want)
+		}
+	}
+
+	// The undivided store holds neither
+	if weeks, err := store.Weeks(); err != nil || len(weeks) != 0 {
+		t.Errorf("Weeks() of the undivided store = %v, %v, want none", weeks, err)
+	}
+}
+
+func TestFileStoreRoundTrip(t *testing.T) {
+	store, err := NewFileStoreFS(vfs.NewMemFS(), "store")
+	if err != nil {
+		t.Fatalf("NewFileStoreFS: %v", err)
+	}
+	for _, week := range []int{4, 2} {
+		if err := store.SaveWeeklyStats(testWeek(week)); err != nil {
+			t.Fatalf("SaveWeeklyStats(%d): %v", week, err)
+		}
+	}
+
+	got, err := store.LoadWeek(4)
+	if err != nil {
+		t.Fatalf("LoadWeek: %v", err)
+	}
+	if want := testWeek(4); !reflect.DeepEqual(got, want) {
+		t.Errorf("LoadWeek(4) =\n%+v\nwant\n%+v", got, want)
+	}
+
+	maxWeek, err := MaxWeek(store)
+	if err != nil || maxWeek != 4 {
+		t.Errorf("MaxWeek() = %d, %v, want 4", maxWeek, err)
+	}
+}
+
+func TestFileStoreMigratesLegacyWeeks(t *testing.T) {
+	fsys := vfs.NewMemFS()
+	store, err := NewFileStoreFS(fsys, "store")
+	if err != nil {
+		t.Fatalf("NewFileStoreFS: %v", err)
+	}
+
+	// Files written before the schema version was recorded hold the stats
+	// directly and have no rating
+	legacy := `{"week": 1, "playerStats": [{"playerName": "JOHN SMITH", "team": "BRIDGE INN 1", "sancPd": "BB"}], "teamStats": []}`
+	if err := vfs.WriteFile(fsys, "store/week_1.json", []byte(legacy)); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	got, err := store.LoadWeek(1)
+	if err != nil {
+		t.Fatalf("LoadWeek: %v", err)
+	}
+	if got.PlayerStats[0].Rating != models.RatingBB {
+		t.Errorf("migrated Rating = %q, want %q", got.PlayerStats[0].Rating, models.RatingBB)
+	}
+}
@@ -0,0 +1,215 @@
+// Package vfs abstracts the filesystem used to write outputs, so exports can
+// target an in-memory filesystem in tests or another backend in production
+package vfs
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+)
+
+// FS is the minimal filesystem needed to save and reload outputs
+type FS interface {
+	Create(name string) (io.WriteCloser, error)
+	Open(name string) (io.ReadCloser, error)
+	MkdirAll(path string, perm fs.FileMode) error
+	ReadDir(name string) ([]string, error)
+}
+
+// OS is the real operating system filesystem
+type OS struct{}
+
+// Create creates or truncates the named file
+func (OS) Create(name string) (io.WriteCloser, error) {
+	return os.Create(name)
+}
+
+// Open opens the named file for reading
+func (OS) Open(name string) (io.ReadCloser, error) {
+	return os.Open(name)
+}
+
+// MkdirAll creates a directory and any missing parents
+func (OS) MkdirAll(path string, perm fs.FileMode) error {
+	return os.MkdirAll(path, perm)
+}
+
+// ReadDir returns the names of the files in a directory, sorted
+func (OS) ReadDir(name string) ([]string, error) {
+	entries, err := os.ReadDir(name)
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			names = append(names, entry.Name())
+		}
+	}
+	return names, nil
+}
+
+// MemFS is an in-memory filesystem. Directories are implicit, so MkdirAll
+// always succeeds. It is safe for concurrent use.
+type MemFS struct {
+	mu    sync.Mutex
+	files map[string][]byte
+}
+
+// NewMemFS returns an empty in-memory filesystem
+func NewMemFS() *MemFS {
+	return &MemFS{files: make(map[string][]byte)}
+}
+
+// Create creates or truncates the named file; its content is stored on Close
+func (m *MemFS) Create(name string) (io.WriteCloser, error) {
+	return &memFile{fs: m, name: filepath.Clean(name)}, nil
+}
+
+// Open opens the named file for reading
+func (m *MemFS) Open(name string) (io.ReadCloser, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	data, found := m.files[filepath.Clean(name)]
+	if !found {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+	}
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+// MkdirAll is a no-op because directories are implicit
+func (m *MemFS) MkdirAll(path string, perm fs.FileMode) error {
+	return nil
+}
+
+// ReadDir returns the names of the files directly inside a directory, sorted
+func (m *MemFS) ReadDir(name string) ([]string, error) {
+	dir := filepath.Clean(name)
+	var names []string
+	for _, file := range m.Files() {
+		if filepath.Dir(file) == dir {
+			names = append(names, filepath.Base(file))
+		}
+	}
+	return names, nil
+}
+
+// Files returns the names of all files, sorted
+func (m *MemFS) Files() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	names := make([]string, 0, len(m.files))
+	for name := range m.files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// memFile buffers writes until it is closed
+type memFile struct {
+	fs   *MemFS
+	name string
+	buf  bytes.Buffer
+}
+
+func (f *memFile) Write(p []byte) (int, error) {
+	return f.buf.Write(p)
+}
+
+func (f *memFile) Close() error {
+	f.fs.mu.Lock()
+	defer f.fs.mu.Unlock()
+	f.fs.files[f.name] = f.buf.Bytes()
+	return nil
+}
+
+// OverlayFS writes to memory while reading from another filesystem, so files
+// already on disk stay readable but nothing is ever written there. Files
+// written to the overlay shadow those below it.
+type OverlayFS struct {
+	lower FS
+	upper *MemFS
+}
+
+// NewOverlayFS returns an overlay over lower
+func NewOverlayFS(lower FS) *OverlayFS {
+	return &OverlayFS{lower: lower, upper: NewMemFS()}
+}
+
+// Create creates or truncates the named file in memory
+func (o *OverlayFS) Create(name string) (io.WriteCloser, error) {
+	return o.upper.Create(name)
+}
+
+// Open opens the named file, preferring the copy written to the overlay
+func (o *OverlayFS) Open(name string) (io.ReadCloser, error) {
+	if f, err := o.upper.Open(name); err == nil {
+		return f, nil
+	}
+	return o.lower.Open(name)
+}
+
+// MkdirAll is a no-op because directories in memory are implicit
+func (o *OverlayFS) MkdirAll(path string, perm fs.FileMode) error {
+	return nil
+}
+
+// ReadDir returns the names of the files in a directory in either layer, sorted
+func (o *OverlayFS) ReadDir(name string) ([]string, error) {
+	seen := make(map[string]bool)
+	names, _ := o.upper.ReadDir(name)
+	for _, n := range names {
+		seen[n] = true
+	}
+
+	lowerNames, err := o.lower.ReadDir(name)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+	for _, n := range lowerNames {
+		if !seen[n] {
+			names = append(names, n)
+		}
+	}
+
+	sort.Strings(names)
+	return names, nil
+}
+
+// Files returns the names of the files written to the overlay, sorted
+func (o *OverlayFS) Files() []string {
+	return o.upper.Files()
+}
+
+// WriteFile writes data to the named file in fsys
+func WriteFile(fsys FS, name string, data []byte) error {
+	f, err := fsys.Create(name)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return fmt.Errorf("error writing %s: %w", name, err)
+	}
+	return f.Close()
+}
+
+// ReadFile reads the named file from fsys
+func ReadFile(fsys FS, name string) ([]byte, error) {
+	f, err := fsys.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return io.ReadAll(f)
+}